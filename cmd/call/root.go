@@ -14,6 +14,7 @@ import (
 	"github.com/easterthebunny/automation-cli/internal/asset"
 	"github.com/easterthebunny/automation-cli/internal/config"
 	cliio "github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
 )
 
 var RootCmd = &cobra.Command{
@@ -31,7 +32,7 @@ var RootCmd = &cobra.Command{
 			return err
 		}
 
-		keys, err := config.ReadPrivateKeysFrom(path.Root.MustRead(config.PrivateKeyConfigFilename))
+		keys, err := keystore.Read(cmd.Context())
 		if err != nil {
 			return err
 		}
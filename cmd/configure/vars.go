@@ -1,27 +1,131 @@
 package configure
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 
+	toml "github.com/pelletier/go-toml/v2"
 	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+	cliio "github.com/easterthebunny/automation-cli/internal/io"
 )
 
-var configSetVarCmd = &cobra.Command{
-	Use:   "set [NAME] [VALUE]",
-	Short: "Shortcut to quickly update config variables",
-	Long:  `Update config variable by name. Only accepts lower case and '.' between nested values.`,
-	Args:  cobra.ExactArgs(2),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return fmt.Errorf("unimplemented")
-	},
+func init() {
+	configGetVarCmd.Flags().StringVar(&outputFormat, "format", "text", "output format (text, json)")
 }
 
-var configGetVarCmd = &cobra.Command{
-	Use:   "get [NAME]",
-	Short: "Read config variables",
-	Long:  `Read config variable by name. Only accepts lower case and '.' between nested values.`,
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return fmt.Errorf("unimplemented")
-	},
+var (
+	outputFormat string
+
+	configSetVarCmd = &cobra.Command{
+		Use:   "set [NAME] [VALUE]",
+		Short: "Shortcut to quickly update config variables",
+		Long: `Update config variable by name. Names are paths of '.' separated fields where list items are selected by
+index. Values are checked against the type of the field before being saved.`,
+		Example: `To change the target probability of the registry offchain config:
+
+$ automation-cli configure set registry.offchain.targetprobability 0.999
+
+To change the log level of the third participant node:
+
+$ automation-cli configure set participants.2.loglevel debug
+
+Lists are set as a whole from a JSON array or comma separated values:
+
+$ automation-cli configure set registry.onchain.registrars 0xabc...,0xdef...`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := cliio.EnvironmentFromContext(cmd.Context())
+			if path == nil {
+				return fmt.Errorf("environment not found")
+			}
+
+			env, err := config.ReadFrom(path.MustRead(config.EnvironmentConfigFilename))
+			if err != nil {
+				return err
+			}
+
+			if err := config.SetPath(&env, args[0], args[1]); err != nil {
+				return err
+			}
+
+			return config.Write(path.MustWrite(config.EnvironmentConfigFilename), env)
+		},
+	}
+
+	configGetVarCmd = &cobra.Command{
+		Use:   "get [NAME]",
+		Short: "Read config variables",
+		Long: `Read config variable by name. Names are paths of '.' separated fields where list items are selected by
+index. Single values are printed as-is and sections are printed as TOML unless another format is selected.`,
+		Example: `To read the deployed registry address:
+
+$ automation-cli configure get registry.address
+
+To read all participant node configurations as JSON:
+
+$ automation-cli configure get participants --format=json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := cliio.EnvironmentFromContext(cmd.Context())
+			if path == nil {
+				return fmt.Errorf("environment not found")
+			}
+
+			env, err := config.ReadFrom(path.MustRead(config.EnvironmentConfigFilename))
+			if err != nil {
+				return err
+			}
+
+			value, err := config.GetPath(env, args[0])
+			if err != nil {
+				return err
+			}
+
+			switch outputFormat {
+			case "json":
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+
+				return encoder.Encode(value)
+			case "text":
+				return writeText(cmd, value)
+			default:
+				return fmt.Errorf("unknown format '%s'", outputFormat)
+			}
+		},
+	}
+)
+
+// writeText prints single values as-is and one per line for lists such that output can be used in shell pipelines.
+// Sections are encoded as TOML.
+func writeText(cmd *cobra.Command, value any) error {
+	reflected := reflect.Indirect(reflect.ValueOf(value))
+
+	//nolint:exhaustive
+	switch reflected.Kind() {
+	case reflect.Struct, reflect.Map:
+		encoder := toml.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndentTables(true)
+
+		return encoder.Encode(value)
+	case reflect.Slice, reflect.Array:
+		for idx := 0; idx < reflected.Len(); idx++ {
+			if idx > 0 && reflect.Indirect(reflected.Index(idx)).Kind() == reflect.Struct {
+				fmt.Fprintln(cmd.OutOrStdout())
+			}
+
+			if err := writeText(cmd, reflected.Index(idx).Interface()); err != nil {
+				return err
+			}
+		}
+	case reflect.Invalid:
+		// nil values print nothing
+	default:
+		fmt.Fprintln(cmd.OutOrStdout(), value)
+	}
+
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/easterthebunny/automation-cli/internal/asset"
 	"github.com/easterthebunny/automation-cli/internal/config"
 	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
 	"github.com/easterthebunny/automation-cli/internal/util"
 )
 
@@ -119,7 +120,7 @@ func prepare(cmd *cobra.Command) (io.Environment, config.Environment, config.Key
 		return io.Environment{}, env, key, err
 	}
 
-	keys, err := config.ReadPrivateKeysFrom(path.Root.MustRead(config.PrivateKeyConfigFilename))
+	keys, err := keystore.Read(cmd.Context())
 	if err != nil {
 		return io.Environment{}, env, key, err
 	}
@@ -0,0 +1,108 @@
+package load
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
+)
+
+var (
+	ErrThresholdExceeded = fmt.Errorf("load stats threshold exceeded")
+
+	policyPath    string
+	compareFormat string
+
+	compareCmd = &cobra.Command{
+		Use:   "compare [BASELINE_SNAPSHOT] [CURRENT_SNAPSHOT]",
+		Short: "Compare delay statistics between two saved load runs",
+		Long: `Compare two snapshots saved by get-stats. Upkeeps are matched by id and the change in perform count and
+p50, p90, p95, p99, max, and average block delay is reported for each upkeep and for all upkeeps combined. Snapshots
+are read from the provided path or by filename from the environment directory.
+
+A json or toml policy file given with --policy limits how far each metric may change. The command exits with an
+error when any threshold is exceeded.`,
+		Example: `$ cat policy.toml
+fail-on-removed = true
+
+[aggregate.p99]
+max-increase = 2
+max-increase-percent = 20
+
+[aggregate.performs]
+max-decrease-percent = 10
+
+[upkeep.max]
+max-increase = 10
+
+$ automation-cli contract verifiable-load compare load-stats-conditional-20240101T120000Z.json \
+  load-stats-conditional-20240108T120000Z.json --policy policy.toml`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := io.EnvironmentFromContext(cmd.Context())
+			if path == nil {
+				return fmt.Errorf("environment not found")
+			}
+
+			snapshots := make([]loadstats.Snapshot, len(args))
+
+			for idx, arg := range args {
+				filePath, err := snapshotPath(*path, arg)
+				if err != nil {
+					return err
+				}
+
+				if snapshots[idx], err = loadstats.Load(filePath); err != nil {
+					return err
+				}
+			}
+
+			var policy loadstats.Policy
+
+			if policyPath != "" {
+				var err error
+
+				if policy, err = loadstats.LoadPolicy(policyPath); err != nil {
+					return err
+				}
+			}
+
+			comparison := loadstats.Compare(snapshots[0], snapshots[1])
+			violations := policy.Evaluate(comparison)
+
+			if err := loadstats.WriteComparison(cmd.OutOrStdout(), comparison, violations, compareFormat); err != nil {
+				return err
+			}
+
+			if len(violations) > 0 {
+				return fmt.Errorf("%w: %d violations", ErrThresholdExceeded, len(violations))
+			}
+
+			return nil
+		},
+	}
+)
+
+// snapshotPath returns the snapshot path as provided when it exists or the path of the snapshot filename in the
+// environment directory.
+func snapshotPath(path io.Environment, snapshot string) (string, error) {
+	if _, err := os.Stat(snapshot); err == nil {
+		return snapshot, nil
+	}
+
+	envPath, err := path.Path()
+	if err != nil {
+		return "", err
+	}
+
+	inEnv := filepath.Join(envPath, filepath.Base(snapshot))
+	if _, err := os.Stat(inEnv); err != nil {
+		return "", fmt.Errorf("snapshot '%s' not found", snapshot)
+	}
+
+	return inEnv, nil
+}
@@ -1,6 +1,13 @@
 package load
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
+)
 
 func init() {
 	RootCmd.PersistentFlags().StringVar(&upkeepType, "type", "conditional", "upkeep type (conditional, log-trigger)")
@@ -10,6 +17,29 @@ func init() {
 	RootCmd.AddCommand(registerCmd)
 	RootCmd.AddCommand(cancelCmd)
 	RootCmd.AddCommand(readStatsCmd)
+	RootCmd.AddCommand(compareCmd)
+	RootCmd.AddCommand(watchCmd)
+
+	readStatsCmd.Flags().StringVar(&outputFormat, "output", loadstats.FormatText,
+		fmt.Sprintf("output format %v", loadstats.Formats))
+	readStatsCmd.Flags().StringVar(&sloPath, "slo", "", "json or toml file with limits to assert")
+	readStatsCmd.Flags().StringToStringVar(&maxLimits, "max", nil, "max value per upkeep by metric (e.g. p99=5,max=20)")
+	readStatsCmd.Flags().Uint64Var(&minPerforms, "min-performs", 0, "min performs per upkeep")
+	readStatsCmd.Flags().BoolVar(&noZeroPerforms, "no-zero-performs", false, "fail when any upkeep has no performs")
+	readStatsCmd.Flags().Uint64Var(&sinceBlock, "since-block", 0, "only include performs after this block")
+	readStatsCmd.Flags().DurationVar(&statsWindow, "window", 0, "only include performs within this duration (e.g. 1h)")
+	readStatsCmd.Flags().Uint16Var(&lastBuckets, "last-buckets", 0, "only include the most recent buckets of each upkeep")
+	readStatsCmd.Flags().BoolVar(&breakdown, "breakdown", false,
+		"include a per bucket summary with a delay histogram and time series")
+	readStatsCmd.MarkFlagsMutuallyExclusive("since-block", "window")
+
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 30*time.Second, "time between polls")
+	watchCmd.Flags().IntVar(&watchWindow, "window", 100, "number of recent performs per upkeep in delay percentiles")
+	watchCmd.Flags().DurationVar(&watchStallAfter, "stall-after", 5*time.Minute,
+		"time without performs after which an upkeep is stalled")
+
+	compareCmd.Flags().StringVar(&policyPath, "policy", "", "json or toml file with change thresholds")
+	compareCmd.Flags().StringVar(&compareFormat, "output", loadstats.FormatText, "output format (text, json)")
 }
 
 var (
@@ -2,20 +2,70 @@ package load
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/easterthebunny/automation-cli/internal/asset"
 	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
 )
 
 var (
+	outputFormat   string
+	sloPath        string
+	maxLimits      map[string]string
+	minPerforms    uint64
+	noZeroPerforms bool
+	sinceBlock     uint64
+	lastBuckets    uint16
+	statsWindow    time.Duration
+	breakdown      bool
+
 	readStatsCmd = &cobra.Command{
 		Use:   "get-stats",
 		Short: "Get delay statistics for load contract",
-		Long:  `Get delay statistics for load contract.`,
+		Long: `Get delay statistics for load contract. Statistics are printed as a table by default or as json, csv, or
+markdown with --output. Each run saves a timestamped json snapshot in the environment directory.
+
+Limits for each upkeep can be asserted with --max, --min-performs, and --no-zero-performs or with a json or toml
+SLO file provided with --slo that can also limit the aggregate stats. Flags are applied over the SLO file. Violations
+are printed to stderr and the command exits with an error that lists the violating upkeep ids.
+
+Statistics can be limited to performs after a block with --since-block, to performs within a duration before the latest
+block with --window, or to the most recent delay buckets of each upkeep with --last-buckets. The contract does not store
+the block of each perform, so --since-block and --window read the delay count of each upkeep at the since block and
+require an rpc with state for that block. Use --breakdown to print a summary of each bucket with a delay histogram and
+time series per upkeep; json output includes the bucket summaries.`,
+		Example: `$ automation-cli contract verifiable-load get-stats --type="log-trigger" --output=csv > stats.csv
+$ automation-cli contract verifiable-load get-stats --max p99=5 --min-performs=100 --no-zero-performs
+
+$ cat slo.toml
+no-zero-performs = true
+
+[aggregate.p99]
+max = 4
+
+[upkeep.p99]
+max = 5
+
+[upkeep.performs]
+min = 100
+
+$ automation-cli contract verifiable-load get-stats --slo slo.toml
+$ automation-cli contract verifiable-load get-stats --window 1h --breakdown
+$ automation-cli contract verifiable-load get-stats --last-buckets 3 --output=json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			_, env, key, err := prepare(cmd)
+			slo, err := statsSLO(cmd)
+			if err != nil {
+				return err
+			}
+
+			path, env, key, err := prepare(cmd)
 			if err != nil {
 				return err
 			}
@@ -30,15 +80,32 @@ var (
 				RegisteredUpkeepInterval: upkeepInterval,
 				CancelBeforeRegister:     cancelUpkeeps,
 				SendLINKBeforeRegister:   sendLINK,
+				StatsSinceBlock:          sinceBlock,
+				StatsWindow:              statsWindow,
+				StatsLastBuckets:         lastBuckets,
+				StatsBreakdown:           breakdown,
 			}
 
-			return runGetStats(cmd.Context(), upkeepType, &env, deployer, vlic)
+			snapshot, err := runGetStats(cmd.Context(), upkeepType, &env, deployer, vlic)
+			if err != nil {
+				return err
+			}
+
+			if err := loadstats.Write(cmd.OutOrStdout(), snapshot, outputFormat); err != nil {
+				return err
+			}
+
+			if err := saveSnapshot(cmd, path, snapshot); err != nil {
+				return err
+			}
+
+			return checkSLO(cmd, slo, snapshot)
 		},
 	}
 )
 
 type statsReader interface {
-	ReadStats(context.Context, *asset.Deployer, asset.VerifiableLoadInteractionConfig) error
+	ReadStats(context.Context, *asset.Deployer, asset.VerifiableLoadInteractionConfig) (loadstats.Snapshot, error)
 }
 
 func runGetStats(
@@ -47,7 +114,7 @@ func runGetStats(
 	env *config.Environment,
 	deployer *asset.Deployer,
 	vlic asset.VerifiableLoadInteractionConfig,
-) error {
+) (loadstats.Snapshot, error) {
 	var (
 		reader statsReader
 		err    error
@@ -56,17 +123,100 @@ func runGetStats(
 	switch contractType {
 	case "conditional":
 		if reader, err = asset.NewVerifiableLoadConditionalDeployable(*env.Registrar, env.ConditionalLoad); err != nil {
-			return err
+			return loadstats.Snapshot{}, err
 		}
 	case "log-trigger":
 		if reader, err = asset.NewVerifiableLoadLogTriggerDeployable(*env.Registrar, env.LogLoad); err != nil {
-			return err
+			return loadstats.Snapshot{}, err
 		}
+	default:
+		return loadstats.Snapshot{}, fmt.Errorf("unknown upkeep type '%s'", contractType)
 	}
 
-	if err := reader.ReadStats(ctx, deployer, vlic); err != nil {
+	return reader.ReadStats(ctx, deployer, vlic)
+}
+
+// statsSLO reads the SLO file, when provided, and applies the limit flags to the upkeep limits.
+func statsSLO(cmd *cobra.Command) (loadstats.SLO, error) {
+	var (
+		slo loadstats.SLO
+		err error
+	)
+
+	if sloPath != "" {
+		if slo, err = loadstats.LoadSLO(sloPath); err != nil {
+			return slo, err
+		}
+	}
+
+	if slo.Upkeep == nil {
+		slo.Upkeep = make(map[string]loadstats.Limit)
+	}
+
+	for metric, value := range maxLimits {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return slo, fmt.Errorf("%w: invalid max for '%s': %s", loadstats.ErrPolicy, metric, err.Error())
+		}
+
+		limit := slo.Upkeep[metric]
+		limit.Max = &parsed
+		slo.Upkeep[metric] = limit
+	}
+
+	if cmd.Flags().Changed("min-performs") {
+		value := float64(minPerforms)
+
+		limit := slo.Upkeep[loadstats.MetricPerforms]
+		limit.Min = &value
+		slo.Upkeep[loadstats.MetricPerforms] = limit
+	}
+
+	if noZeroPerforms {
+		slo.NoZeroPerforms = true
+	}
+
+	return slo, slo.Validate()
+}
+
+// checkSLO prints each violation to stderr and returns an error listing the violating upkeep ids.
+func checkSLO(cmd *cobra.Command, slo loadstats.SLO, snapshot loadstats.Snapshot) error {
+	violations := slo.Check(snapshot)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	for _, violation := range violations {
+		fmt.Fprintf(cmd.ErrOrStderr(), "VIOLATION: %s\n", violation)
+	}
+
+	ids := loadstats.ViolatingUpkeeps(violations)
+	if len(ids) == 0 {
+		return fmt.Errorf("%w: %d violations in aggregate stats", ErrThresholdExceeded, len(violations))
+	}
+
+	return fmt.Errorf("%w: %d violations for upkeeps %s",
+		ErrThresholdExceeded, len(violations), strings.Join(ids, ", "))
+}
+
+// saveSnapshot writes the snapshot to the environment directory. The snapshot location is printed to stderr such that
+// machine-readable output on stdout is unchanged. Nothing is saved in a dry run.
+func saveSnapshot(cmd *cobra.Command, path io.Environment, snapshot loadstats.Snapshot) error {
+	if io.DryRunFromContext(cmd.Context()) {
+		return nil
+	}
+
+	filename, err := loadstats.Save(path, snapshot)
+	if err != nil {
 		return err
 	}
 
+	envPath, err := path.Path()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "snapshot saved to %s/%s\n", envPath, filename)
+
 	return nil
 }
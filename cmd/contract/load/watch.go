@@ -0,0 +1,129 @@
+package load
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
+)
+
+const clearScreen = "\033[H\033[2J"
+
+var (
+	watchInterval   time.Duration
+	watchWindow     int
+	watchStallAfter time.Duration
+
+	watchCmd = &cobra.Command{
+		Use:   "watch",
+		Short: "Continuously display delay statistics for load contract",
+		Long: `Poll the load contract on an interval and display a rolling view of each upkeep with the perform count,
+the perform rate per minute, and delay percentiles over the most recent performs. Upkeeps that have not performed for
+the stall duration are marked as stalled. Contract reads are skipped when no new block was produced, in which case the
+view is refreshed from the cached delays such that a halted chain shows as stalled upkeeps, and only delay buckets that
+changed since the previous poll are fetched. Stop watching with Ctrl+C.`,
+		Example: `$ automation-cli contract verifiable-load watch --interval 30s --window 50 --stall-after 2m`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if watchInterval <= 0 {
+				return fmt.Errorf("interval must be greater than zero")
+			}
+
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, err := asset.NewDeployer(&env, key)
+			if err != nil {
+				return err
+			}
+
+			watcher, err := newWatcher(upkeepType, &env, deployer)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			return runWatch(ctx, cmd, watcher, loadstats.NewTracker(watchWindow, watchStallAfter))
+		},
+	}
+)
+
+func newWatcher(
+	contractType string,
+	env *config.Environment,
+	deployer *asset.Deployer,
+) (*asset.VerifiableLoadWatcher, error) {
+	switch contractType {
+	case "conditional":
+		deployable, err := asset.NewVerifiableLoadConditionalDeployable(*env.Registrar, env.ConditionalLoad)
+		if err != nil {
+			return nil, err
+		}
+
+		return deployable.Watcher(deployer)
+	case "log-trigger":
+		deployable, err := asset.NewVerifiableLoadLogTriggerDeployable(*env.Registrar, env.LogLoad)
+		if err != nil {
+			return nil, err
+		}
+
+		return deployable.Watcher(deployer)
+	default:
+		return nil, fmt.Errorf("unknown upkeep type '%s'", contractType)
+	}
+}
+
+// runWatch polls until the context is cancelled. Poll failures are printed to stderr and retried on the next interval
+// such that a single failed rpc call does not end a long running watch.
+func runWatch(
+	ctx context.Context,
+	cmd *cobra.Command,
+	watcher *asset.VerifiableLoadWatcher,
+	tracker *loadstats.Tracker,
+) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := watchOnce(ctx, cmd, watcher, tracker); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "poll failed: %s\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func watchOnce(
+	ctx context.Context,
+	cmd *cobra.Command,
+	watcher *asset.VerifiableLoadWatcher,
+	tracker *loadstats.Tracker,
+) error {
+	poll, err := watcher.Poll(ctx)
+	if err != nil {
+		return err
+	}
+
+	view, err := tracker.Update(poll)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), clearScreen)
+
+	return loadstats.WriteWatch(cmd.OutOrStdout(), view)
+}
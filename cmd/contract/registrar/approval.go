@@ -0,0 +1,271 @@
+package registrar
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/io"
+)
+
+//nolint:gochecknoinits
+func init() {
+	RootCmd.AddCommand(setTriggerConfigCmd)
+	RootCmd.AddCommand(pendingCmd)
+	RootCmd.AddCommand(approveCmd)
+	RootCmd.AddCommand(cancelCmd)
+
+	pendingCmd.Flags().StringVar(&outputFormat, "format", "text", "output format (text, json)")
+
+	for _, cmd := range []*cobra.Command{pendingCmd, approveCmd} {
+		cmd.Flags().Uint64Var(&fromBlock, "from-block", 0, "first block to read registration requests from")
+	}
+}
+
+var (
+	outputFormat string
+	fromBlock    uint64
+
+	setTriggerConfigCmd = &cobra.Command{
+		Use:   "set-trigger-config [TRIGGER_TYPE] [AUTO_APPROVE_TYPE] [MAX_ALLOWED]",
+		Short: "Set the auto-approve type and limit for a trigger type",
+		Long: `Set how registrations of a trigger type (conditional, log) are approved. Registrations are auto-approved
+for all senders (all), only for allowed senders (allowlist), or never (disabled) until MAX_ALLOWED registrations of the
+trigger type were auto-approved. Registrations that are not auto-approved are pending until approved or cancelled. The
+key must be the registrar owner.`,
+		Example: `To require manual approval of all log trigger registrations:
+
+$ automation-cli contract registrar set-trigger-config log disabled 0`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			triggerType, err := parseTriggerType(args[0])
+			if err != nil {
+				return err
+			}
+
+			autoApproveType, err := parseAutoApproveType(args[1])
+			if err != nil {
+				return err
+			}
+
+			maxAllowed, err := strconv.ParseUint(args[2], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid max allowed: %w", err)
+			}
+
+			path, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, registrar, err := connectRegistrarV21(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			if err := registrar.SetTriggerConfig(
+				cmd.Context(), deployer, triggerType, autoApproveType, uint32(maxAllowed),
+			); err != nil {
+				return err
+			}
+
+			return config.Write(path.MustWrite(config.EnvironmentConfigFilename), env)
+		},
+	}
+
+	pendingCmd = &cobra.Command{
+		Use:   "pending",
+		Short: "List registrations pending approval",
+		Long: `List registration requests from RegistrationRequested logs that the registrar still holds as pending along
+with the auto-approve settings and approved counts for each trigger type.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, registrar, err := connectRegistrarV21(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			triggers, err := registrar.TriggerRegistrations(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			pending, err := registrar.PendingRegistrations(cmd.Context(), fromBlock)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat != "text" {
+				return writeJSON(cmd, struct {
+					Triggers []asset.TriggerRegistration `json:"triggers"`
+					Pending  []asset.PendingRegistration `json:"pending"`
+				}{Triggers: triggers, Pending: pending})
+			}
+
+			triggerWriter := table.NewWriter()
+			triggerWriter.SetStyle(table.StyleLight)
+			triggerWriter.AppendHeader(table.Row{"Trigger", "Auto Approve", "Max Allowed", "Approved"})
+
+			for _, trigger := range triggers {
+				triggerWriter.AppendRow(table.Row{
+					asset.TriggerTypeName(trigger.TriggerType),
+					asset.AutoApproveTypeName(trigger.AutoApproveType),
+					trigger.MaxAllowed,
+					trigger.ApprovedCount,
+				})
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), triggerWriter.Render())
+
+			if len(pending) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "no pending registrations")
+
+				return nil
+			}
+
+			writer := table.NewWriter()
+			writer.SetStyle(table.StyleLight)
+			writer.AppendHeader(table.Row{"Hash", "Name", "Target", "Admin", "Trigger", "Gas Limit", "Balance", "Block"})
+
+			for _, registration := range pending {
+				writer.AppendRow(table.Row{
+					registration.Hash.Hex(),
+					registration.Name,
+					registration.UpkeepContract.Hex(),
+					registration.AdminAddress.Hex(),
+					asset.TriggerTypeName(registration.TriggerType),
+					registration.GasLimit,
+					registration.Balance,
+					registration.Block,
+				})
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), writer.Render())
+
+			return nil
+		},
+	}
+
+	approveCmd = &cobra.Command{
+		Use:   "approve [HASH]",
+		Short: "Approve a pending registration",
+		Long: `Approve a pending registration with the parameters from its RegistrationRequested log and print the new
+upkeep id. The key must be the registrar owner.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hash, err := parseRequestHash(args[0])
+			if err != nil {
+				return err
+			}
+
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, registrar, err := connectRegistrarV21(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			upkeepID, err := registrar.Approve(cmd.Context(), deployer, hash, fromBlock)
+			if err != nil {
+				return err
+			}
+
+			if !io.DryRunFromContext(cmd.Context()) {
+				fmt.Fprintf(cmd.OutOrStdout(), "registration approved with upkeep id %s\n", upkeepID)
+			}
+
+			return nil
+		},
+	}
+
+	cancelCmd = &cobra.Command{
+		Use:   "cancel [HASH]",
+		Short: "Cancel a pending registration",
+		Long: `Cancel a pending registration and refund the LINK amount to the registration admin. The key must be the
+registration admin or the registrar owner.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hash, err := parseRequestHash(args[0])
+			if err != nil {
+				return err
+			}
+
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, registrar, err := connectRegistrarV21(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			if err := registrar.Cancel(cmd.Context(), deployer, hash); err != nil {
+				return err
+			}
+
+			if !io.DryRunFromContext(cmd.Context()) {
+				fmt.Fprintf(cmd.OutOrStdout(), "registration %s cancelled\n", hash)
+			}
+
+			return nil
+		},
+	}
+)
+
+func parseTriggerType(value string) (uint8, error) {
+	switch value {
+	case "conditional":
+		return asset.ConditionalTrigger, nil
+	case "log":
+		return asset.LogTrigger, nil
+	default:
+		return 0, fmt.Errorf("unknown trigger type '%s'", value)
+	}
+}
+
+func parseAutoApproveType(value string) (uint8, error) {
+	switch value {
+	case "disabled":
+		return asset.AutoApproveDisabled, nil
+	case "allowlist":
+		return asset.AutoApproveAllowlist, nil
+	case "all":
+		return asset.AutoApproveAll, nil
+	default:
+		return 0, fmt.Errorf("unknown auto-approve type '%s'", value)
+	}
+}
+
+func parseRequestHash(value string) (common.Hash, error) {
+	if len(common.FromHex(value)) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid request hash '%s'", value)
+	}
+
+	return common.HexToHash(value), nil
+}
+
+func writeJSON(cmd *cobra.Command, value any) error {
+	if outputFormat != "json" {
+		return fmt.Errorf("unknown format '%s'", outputFormat)
+	}
+
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
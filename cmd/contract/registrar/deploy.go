@@ -8,6 +8,7 @@ import (
 	"github.com/easterthebunny/automation-cli/internal/asset"
 	"github.com/easterthebunny/automation-cli/internal/config"
 	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
 )
 
 var (
@@ -30,25 +31,15 @@ var (
 				return fmt.Errorf("link token and registry required")
 			}
 
-			env.Registrar = &config.AutomationRegistrarV21Contract{
-				Type:    config.AutomationRegistrarContractType,
-				Version: "v2.1",
-				MinLink: 0,
-				AutoApprovals: []config.AutomationRegistrarV21AutoApprovalConfig{
-					{
-						TriggerType:           0,
-						AutoApproveType:       2,
-						AutoApproveMaxAllowed: 1_000,
-					},
-					{
-						TriggerType:           1,
-						AutoApproveType:       2,
-						AutoApproveMaxAllowed: 1_000,
-					},
-				},
+			env.Registrar, err = asset.NewDefaultRegistrarConfig(env.Registry.ContractVersion())
+			if err != nil {
+				return err
 			}
 
-			deployable := asset.NewRegistrarV21Deployable(*env.LinkToken, *env.Registry, env.Registrar)
+			deployable, err := asset.NewRegistrarDeployable(*env.LinkToken, *env.Registry, env.Registrar)
+			if err != nil {
+				return err
+			}
 
 			if _, err := deployable.Deploy(cmd.Context(), deployer); err != nil {
 				return err
@@ -78,7 +69,7 @@ func prepare(cmd *cobra.Command) (io.Environment, config.Environment, config.Key
 		return io.Environment{}, env, key, err
 	}
 
-	keys, err := config.ReadPrivateKeysFrom(path.Root.MustRead(config.PrivateKeyConfigFilename))
+	keys, err := keystore.Read(cmd.Context())
 	if err != nil {
 		return io.Environment{}, env, key, err
 	}
@@ -0,0 +1,242 @@
+package registrar
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/util"
+)
+
+//nolint:gochecknoinits
+func init() {
+	RootCmd.AddCommand(registerUpkeepCmd)
+
+	registerUpkeepCmd.Flags().StringVar(&registration.name, "name", "automation-cli upkeep", "display name of the upkeep")
+	registerUpkeepCmd.Flags().StringVar(
+		&registration.trigger, "trigger", "conditional", "trigger type of the upkeep (conditional, log)")
+	registerUpkeepCmd.Flags().Uint32Var(&registration.gasLimit, "gas-limit", 500_000, "perform gas limit of the upkeep")
+	registerUpkeepCmd.Flags().StringVar(
+		&registration.checkData, "check-data", "0x", "hex encoded check data for conditional upkeeps")
+	registerUpkeepCmd.Flags().StringVar(
+		&registration.offchainConfig, "offchain-config", "0x", "hex encoded offchain config of the upkeep")
+	registerUpkeepCmd.Flags().StringVar(
+		&registration.admin, "admin", "", "admin address of the upkeep (default is the key address)")
+	registerUpkeepCmd.Flags().StringVar(
+		&registration.amount, "amount", "5e18", "LINK in juels to fund the upkeep with; amounts can be written as 5e18")
+
+	registerUpkeepCmd.Flags().StringVar(
+		&registration.logContract, "log-contract", "", "address emitting the trigger log for log trigger upkeeps")
+	registerUpkeepCmd.Flags().Uint8Var(
+		&registration.selector, "filter-selector", 0, "bit mask enabling topic 1 (1), topic 2 (2), and topic 3 (4) filters")
+	registerUpkeepCmd.Flags().StringVar(&registration.topics[0], "topic0", "", "event signature of the trigger log")
+	registerUpkeepCmd.Flags().StringVar(&registration.topics[1], "topic1", "", "first indexed topic filter")
+	registerUpkeepCmd.Flags().StringVar(&registration.topics[2], "topic2", "", "second indexed topic filter")
+	registerUpkeepCmd.Flags().StringVar(&registration.topics[3], "topic3", "", "third indexed topic filter")
+}
+
+var (
+	registration struct {
+		name           string
+		trigger        string
+		gasLimit       uint32
+		checkData      string
+		offchainConfig string
+		admin          string
+		amount         string
+		logContract    string
+		selector       uint8
+		topics         [4]string
+	}
+
+	registerUpkeepCmd = &cobra.Command{
+		Use:   "register-upkeep [TARGET]",
+		Short: "Register an upkeep through the registrar",
+		Long: `Register an upkeep for the target contract by sending the registration and LINK amount to the registrar
+with LINK transferAndCall. The upkeep id is printed when the registration is auto-approved and the request hash is
+printed when the registration is pending approval.`,
+		Example: `To register a conditional upkeep funded with 5 LINK:
+
+$ automation-cli contract registrar register-upkeep [TARGET] --gas-limit=1000000
+
+To register a log trigger upkeep for an event on an emitter contract:
+
+$ automation-cli contract registrar register-upkeep [TARGET] --trigger=log \
+	--log-contract=[EMITTER] --topic0=[EVENT_SIG]`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params, err := registrationParams(args[0])
+			if err != nil {
+				return err
+			}
+
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, registrar, err := connectRegistrar(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			if params.AdminAddress == (common.Address{}) {
+				params.AdminAddress = deployer.Address
+			}
+
+			result, err := registrar.RegisterUpkeep(cmd.Context(), deployer, params)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case io.DryRunFromContext(cmd.Context()):
+			case result.Approved:
+				fmt.Fprintf(cmd.OutOrStdout(), "upkeep registered with id %s\n", result.UpkeepID)
+			default:
+				fmt.Fprintf(cmd.OutOrStdout(), "registration pending approval with hash %s\n", result.Hash)
+			}
+
+			return nil
+		},
+	}
+)
+
+// registrationParams builds the registration from flags. The admin is left empty when not provided.
+func registrationParams(target string) (asset.RegistrationParams, error) {
+	var params asset.RegistrationParams
+
+	if !common.IsHexAddress(target) {
+		return params, fmt.Errorf("target address must be hex encoded")
+	}
+
+	amount, err := util.ParseExp(registration.amount)
+	if err != nil {
+		return params, err
+	}
+
+	checkData, err := hexutil.Decode(registration.checkData)
+	if err != nil {
+		return params, fmt.Errorf("invalid check data: %w", err)
+	}
+
+	offchainConfig, err := hexutil.Decode(registration.offchainConfig)
+	if err != nil {
+		return params, fmt.Errorf("invalid offchain config: %w", err)
+	}
+
+	params = asset.RegistrationParams{
+		Name:           registration.name,
+		EncryptedEmail: []byte{},
+		UpkeepContract: common.HexToAddress(target),
+		GasLimit:       registration.gasLimit,
+		CheckData:      checkData,
+		OffchainConfig: offchainConfig,
+		TriggerConfig:  []byte{},
+		Amount:         amount,
+	}
+
+	if registration.admin != "" {
+		if !common.IsHexAddress(registration.admin) {
+			return params, fmt.Errorf("admin address must be hex encoded")
+		}
+
+		params.AdminAddress = common.HexToAddress(registration.admin)
+	}
+
+	if params.TriggerType, err = parseTriggerType(registration.trigger); err != nil {
+		return params, err
+	}
+
+	if params.TriggerType == asset.LogTrigger {
+		if params.TriggerConfig, err = logTriggerConfig(); err != nil {
+			return params, err
+		}
+	}
+
+	return params, nil
+}
+
+func logTriggerConfig() ([]byte, error) {
+	if !common.IsHexAddress(registration.logContract) {
+		return nil, fmt.Errorf("log trigger upkeeps require a log contract address with --log-contract")
+	}
+
+	conf := asset.LogTriggerConfig{
+		ContractAddress: common.HexToAddress(registration.logContract),
+		FilterSelector:  registration.selector,
+	}
+
+	for idx, topic := range []*common.Hash{&conf.Topic0, &conf.Topic1, &conf.Topic2, &conf.Topic3} {
+		if registration.topics[idx] != "" {
+			*topic = common.HexToHash(registration.topics[idx])
+		}
+	}
+
+	return asset.EncodeLogTriggerConfig(conf)
+}
+
+func connectRegistrar(
+	cmd *cobra.Command,
+	env *config.Environment,
+	key config.Key,
+) (*asset.Deployer, asset.RegistrarDeployable, error) {
+	if env.Registrar == nil || env.Registrar.Address == "" {
+		return nil, nil, fmt.Errorf("registrar does not exist")
+	}
+
+	deployer, err := asset.NewDeployer(env, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		link     config.LinkTokenContract
+		registry config.AutomationRegistryV21Contract
+	)
+
+	if env.LinkToken != nil {
+		link = *env.LinkToken
+	}
+
+	if env.Registry != nil {
+		registry = *env.Registry
+	}
+
+	registrar, err := asset.NewRegistrarDeployable(link, registry, env.Registrar)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := registrar.Connect(cmd.Context(), deployer); err != nil {
+		return nil, nil, err
+	}
+
+	return deployer, registrar, nil
+}
+
+// connectRegistrarV21 connects to the registrar for commands that manage registration requests and trigger
+// configurations, which a v2.0 registrar does not support.
+func connectRegistrarV21(
+	cmd *cobra.Command,
+	env *config.Environment,
+	key config.Key,
+) (*asset.Deployer, *asset.RegistrarV21Deployable, error) {
+	deployer, registrar, err := connectRegistrar(cmd, env, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	registrar21, ok := registrar.(*asset.RegistrarV21Deployable)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: command requires a v2.1 registrar; found %s",
+			asset.ErrUnsupportedVersion, env.Registrar.ContractVersion())
+	}
+
+	return deployer, registrar21, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 
+	"github.com/easterthebunny/automation-cli/internal/asset"
 	"github.com/easterthebunny/automation-cli/internal/config"
 	"github.com/easterthebunny/automation-cli/internal/io"
 )
@@ -36,22 +37,9 @@ var (
 			}
 
 			if env.Registrar == nil {
-				env.Registrar = &config.AutomationRegistrarV21Contract{
-					Type:    config.AutomationRegistrarContractType,
-					Version: "v2.1",
-					MinLink: 0,
-					AutoApprovals: []config.AutomationRegistrarV21AutoApprovalConfig{
-						{
-							TriggerType:           0,
-							AutoApproveType:       2,
-							AutoApproveMaxAllowed: 1_000,
-						},
-						{
-							TriggerType:           1,
-							AutoApproveType:       2,
-							AutoApproveMaxAllowed: 1_000,
-						},
-					},
+				env.Registrar, err = asset.NewDefaultRegistrarConfig(env.Registry.ContractVersion())
+				if err != nil {
+					return err
 				}
 			}
 
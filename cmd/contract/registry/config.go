@@ -1,20 +1,34 @@
 package registry
 
 import (
+	"bufio"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 
 	"github.com/easterthebunny/automation-cli/internal/asset"
 	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/io"
 )
 
-// TODO: override onchain, offchain, and ocr configs from json input
-
 var setConfigCmd = &cobra.Command{
 	Use:   "set-config",
 	Short: "Set the configuration for the registry",
-	Long:  `Set the configuration for the registry including on-chain config and off-chain config.`,
+	Long: `Set the configuration for the registry including on-chain config and off-chain config. Values from a json
+or toml file provided with --from are applied over the stored onchain, offchain, and ocrnetwork config. The changes
+against the latest on-chain config are printed and must be confirmed before the config is sent.`,
+	Example: `To raise the check gas limit and slow down OCR rounds:
+
+$ cat overlay.json
+{
+  "onchain": {"checkGasLimit": 10000000},
+  "ocrNetwork": {"deltaRound": "2s", "maxFaultyNodes": 1}
+}
+$ automation-cli contract registry set-config --from overlay.json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		path, env, key, err := prepare(cmd)
 		if err != nil {
@@ -30,14 +44,37 @@ var setConfigCmd = &cobra.Command{
 			return fmt.Errorf("registry does not exist")
 		}
 
-		env.Registry.OCRNetwork.MaxFaultyNodes = int(maxFaulty)
+		if configPath != "" {
+			if err := overlayFromFile(&env, configPath); err != nil {
+				return err
+			}
+		}
+
+		// the overlay file takes precedence over the flag default
+		if configPath == "" || cmd.Flags().Changed("max-faulty") {
+			env.Registry.OCRNetwork.MaxFaultyNodes = int(maxFaulty)
+		}
 
-		interactable := asset.NewRegistryV21Deployable(*env.LinkToken, *env.LinkETH, *env.FastGas, env.Registry)
+		interactable, err := asset.NewRegistryDeployable(*env.LinkToken, *env.LinkETH, *env.FastGas, env.Registry)
+		if err != nil {
+			return err
+		}
 
 		if _, err := interactable.Connect(cmd.Context(), deployer); err != nil {
 			return err
 		}
 
+		registry, ok := interactable.(asset.RegistryConfigReader)
+		if !ok {
+			return fmt.Errorf("%w: the on-chain config of a %s registry cannot be compared",
+				asset.ErrUnsupportedVersion, interactable.Version())
+		}
+
+		confirmed, err := confirmConfigChanges(cmd, registry, env.Participants)
+		if err != nil || !confirmed {
+			return err
+		}
+
 		if err := interactable.SetOffchainConfig(cmd.Context(), deployer, env.Participants); err != nil {
 			return err
 		}
@@ -45,3 +82,67 @@ var setConfigCmd = &cobra.Command{
 		return config.Write(path.MustWrite(config.EnvironmentConfigFilename), env)
 	},
 }
+
+func overlayFromFile(env *config.Environment, filePath string) error {
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(filePath)), ".")
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+
+	return config.OverlayRegistryConfig(env, file, format)
+}
+
+// confirmConfigChanges prints the changes between the latest on-chain config and the proposed config and asks for
+// confirmation unless --yes is set or the command is a dry run.
+func confirmConfigChanges(
+	cmd *cobra.Command,
+	registry asset.RegistryConfigReader,
+	nodes []config.NodeConfig,
+) (bool, error) {
+	latest, err := registry.LatestConfig(cmd.Context())
+	if err != nil {
+		return false, err
+	}
+
+	proposed, err := registry.ProposedConfig(nodes)
+	if err != nil {
+		return false, err
+	}
+
+	changes := config.Diff("", latest.Values, proposed)
+
+	if len(changes) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "no changes to the on-chain config")
+	} else {
+		writer := table.NewWriter()
+		writer.SetStyle(table.StyleLight)
+		writer.AppendHeader(table.Row{"Field", "On-Chain", "Proposed"})
+
+		for _, change := range changes {
+			writer.AppendRow(table.Row{change.Path, change.Old, change.New})
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), writer.Render())
+	}
+
+	if assumeYes || io.DryRunFromContext(cmd.Context()) {
+		return true, nil
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), "Send the config to the registry? [y/N]: ")
+
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, err
+	}
+
+	if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+		fmt.Fprintln(cmd.OutOrStdout(), "set-config aborted")
+
+		return false, nil
+	}
+
+	return true, nil
+}
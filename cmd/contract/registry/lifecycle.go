@@ -0,0 +1,287 @@
+package registry
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/util"
+)
+
+func init() {
+	upkeepCmd.AddCommand(upkeepAddFundsCmd)
+	upkeepCmd.AddCommand(upkeepPauseCmd)
+	upkeepCmd.AddCommand(upkeepUnpauseCmd)
+	upkeepCmd.AddCommand(upkeepCancelCmd)
+	upkeepCmd.AddCommand(upkeepWithdrawFundsCmd)
+	upkeepCmd.AddCommand(upkeepSetGasLimitCmd)
+	upkeepCmd.AddCommand(upkeepSetCheckDataCmd)
+	upkeepCmd.AddCommand(upkeepSetTriggerConfigCmd)
+	upkeepCmd.AddCommand(upkeepSetOffchainConfigCmd)
+	upkeepCmd.AddCommand(upkeepTransferAdminCmd)
+	upkeepCmd.AddCommand(upkeepAcceptAdminCmd)
+
+	upkeepWithdrawFundsCmd.Flags().StringVar(
+		&withdrawTo, "to", "", "address to receive funds (default is the key address)")
+
+	upkeepSetTriggerConfigCmd.Flags().StringVar(
+		&logTrigger.contract, "log-contract", "", "address emitting the trigger log")
+	upkeepSetTriggerConfigCmd.Flags().Uint8Var(
+		&logTrigger.selector, "filter-selector", 0, "bit mask enabling topic 1 (1), topic 2 (2), and topic 3 (4) filters")
+	upkeepSetTriggerConfigCmd.Flags().StringVar(&logTrigger.topics[0], "topic0", "", "event signature of the trigger log")
+	upkeepSetTriggerConfigCmd.Flags().StringVar(&logTrigger.topics[1], "topic1", "", "first indexed topic filter")
+	upkeepSetTriggerConfigCmd.Flags().StringVar(&logTrigger.topics[2], "topic2", "", "second indexed topic filter")
+	upkeepSetTriggerConfigCmd.Flags().StringVar(&logTrigger.topics[3], "topic3", "", "third indexed topic filter")
+}
+
+// upkeepTarget is the upkeep an action is run for along with the connected registry.
+type upkeepTarget struct {
+	deployer *asset.Deployer
+	registry *asset.RegistryV21Deployable
+	id       *big.Int
+}
+
+type upkeepAction func(*cobra.Command, upkeepTarget, []string) error
+
+var (
+	withdrawTo string
+	logTrigger struct {
+		contract string
+		selector uint8
+		topics   [4]string
+	}
+
+	upkeepAddFundsCmd = newUpkeepActionCmd(
+		"add-funds [UPKEEP_ID] [AMOUNT]",
+		"Add LINK to an upkeep balance",
+		`Transfer LINK in juels from the key to the registry for the upkeep. Amounts can be written as 5e18.`,
+		1,
+		func(cmd *cobra.Command, target upkeepTarget, args []string) error {
+			amount, err := util.ParseExp(args[0])
+			if err != nil {
+				return err
+			}
+
+			return target.registry.AddFunds(cmd.Context(), target.deployer, target.id, amount)
+		},
+	)
+
+	upkeepPauseCmd = newUpkeepActionCmd(
+		"pause [UPKEEP_ID]",
+		"Pause an upkeep",
+		`Pause an upkeep such that it is no longer checked or performed. The key must be the upkeep admin.`,
+		0,
+		func(cmd *cobra.Command, target upkeepTarget, _ []string) error {
+			return target.registry.PauseUpkeep(cmd.Context(), target.deployer, target.id)
+		},
+	)
+
+	upkeepUnpauseCmd = newUpkeepActionCmd(
+		"unpause [UPKEEP_ID]",
+		"Unpause an upkeep",
+		`Unpause a paused upkeep. The key must be the upkeep admin.`,
+		0,
+		func(cmd *cobra.Command, target upkeepTarget, _ []string) error {
+			return target.registry.UnpauseUpkeep(cmd.Context(), target.deployer, target.id)
+		},
+	)
+
+	upkeepCancelCmd = newUpkeepActionCmd(
+		"cancel [UPKEEP_ID]",
+		"Cancel an upkeep",
+		`Cancel an upkeep. The remaining balance can be withdrawn with withdraw-funds after the registry cancellation
+delay. The key must be the upkeep admin or the registry owner.`,
+		0,
+		func(cmd *cobra.Command, target upkeepTarget, _ []string) error {
+			return target.registry.CancelUpkeep(cmd.Context(), target.deployer, target.id)
+		},
+	)
+
+	upkeepWithdrawFundsCmd = newUpkeepActionCmd(
+		"withdraw-funds [UPKEEP_ID]",
+		"Withdraw the balance of a cancelled upkeep",
+		`Withdraw the remaining balance of a cancelled upkeep to the key address or the address provided by --to.`,
+		0,
+		func(cmd *cobra.Command, target upkeepTarget, _ []string) error {
+			to := target.deployer.Address
+
+			if withdrawTo != "" {
+				if !common.IsHexAddress(withdrawTo) {
+					return fmt.Errorf("withdraw address must be hex encoded")
+				}
+
+				to = common.HexToAddress(withdrawTo)
+			}
+
+			return target.registry.WithdrawFunds(cmd.Context(), target.deployer, target.id, to)
+		},
+	)
+
+	upkeepSetGasLimitCmd = newUpkeepActionCmd(
+		"set-gas-limit [UPKEEP_ID] [GAS_LIMIT]",
+		"Set the perform gas limit of an upkeep",
+		`Set the perform gas limit of an upkeep. The key must be the upkeep admin.`,
+		1,
+		func(cmd *cobra.Command, target upkeepTarget, args []string) error {
+			gasLimit, err := strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid gas limit: %w", err)
+			}
+
+			return target.registry.SetUpkeepGasLimit(cmd.Context(), target.deployer, target.id, uint32(gasLimit))
+		},
+	)
+
+	upkeepSetCheckDataCmd = newUpkeepActionCmd(
+		"set-check-data [UPKEEP_ID] [HEX]",
+		"Set the check data of a conditional upkeep",
+		`Set the hex encoded check data passed to checkUpkeep of a conditional upkeep. The key must be the upkeep admin.`,
+		1,
+		func(cmd *cobra.Command, target upkeepTarget, args []string) error {
+			checkData, err := hexutil.Decode(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid check data: %w", err)
+			}
+
+			return target.registry.SetUpkeepCheckData(cmd.Context(), target.deployer, target.id, checkData)
+		},
+	)
+
+	upkeepSetTriggerConfigCmd = newUpkeepActionCmd(
+		"set-trigger-config [UPKEEP_ID] [HEX]",
+		"Set the trigger config of an upkeep",
+		`Set the trigger config of an upkeep from hex encoded bytes or, for log trigger upkeeps, from the log filter
+flags. The key must be the upkeep admin.`,
+		-1,
+		func(cmd *cobra.Command, target upkeepTarget, args []string) error {
+			triggerConfig, err := triggerConfigFrom(args)
+			if err != nil {
+				return err
+			}
+
+			return target.registry.SetUpkeepTriggerConfig(cmd.Context(), target.deployer, target.id, triggerConfig)
+		},
+	)
+
+	upkeepSetOffchainConfigCmd = newUpkeepActionCmd(
+		"set-offchain-config [UPKEEP_ID] [HEX]",
+		"Set the offchain config of an upkeep",
+		`Set the hex encoded offchain config of an upkeep. The key must be the upkeep admin.`,
+		1,
+		func(cmd *cobra.Command, target upkeepTarget, args []string) error {
+			offchainConfig, err := hexutil.Decode(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid offchain config: %w", err)
+			}
+
+			return target.registry.SetUpkeepOffchainConfig(cmd.Context(), target.deployer, target.id, offchainConfig)
+		},
+	)
+
+	upkeepTransferAdminCmd = newUpkeepActionCmd(
+		"transfer-admin [UPKEEP_ID] [ADDRESS]",
+		"Propose a new admin for an upkeep",
+		`Propose a new admin for an upkeep. The transfer completes when the proposed admin runs accept-admin.`,
+		1,
+		func(cmd *cobra.Command, target upkeepTarget, args []string) error {
+			if !common.IsHexAddress(args[0]) {
+				return fmt.Errorf("provided address must be hex encoded")
+			}
+
+			return target.registry.TransferUpkeepAdmin(cmd.Context(), target.deployer, target.id, common.HexToAddress(args[0]))
+		},
+	)
+
+	upkeepAcceptAdminCmd = newUpkeepActionCmd(
+		"accept-admin [UPKEEP_ID]",
+		"Accept a proposed admin transfer of an upkeep",
+		`Accept the admin role of an upkeep where the key is the proposed admin.`,
+		0,
+		func(cmd *cobra.Command, target upkeepTarget, _ []string) error {
+			return target.registry.AcceptUpkeepAdmin(cmd.Context(), target.deployer, target.id)
+		},
+	)
+)
+
+// newUpkeepActionCmd creates a command that takes an upkeep id followed by the provided number of arguments and runs
+// the action against the environment registry. A negative argument count allows up to that many arguments.
+func newUpkeepActionCmd(use, short, long string, extraArgs int, action upkeepAction) *cobra.Command {
+	args := cobra.ExactArgs(1 + extraArgs)
+	if extraArgs < 0 {
+		args = cobra.RangeArgs(1, 1-extraArgs)
+	}
+
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  long,
+		Args:  args,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			upkeepID, err := parseUpkeepID(args[0])
+			if err != nil {
+				return err
+			}
+
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, registry, err := connectRegistry(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			target := upkeepTarget{deployer: deployer, registry: registry, id: upkeepID}
+
+			if err := action(cmd, target, args[1:]); err != nil {
+				return err
+			}
+
+			if !io.DryRunFromContext(cmd.Context()) {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s complete for upkeep %s\n", cmd.Name(), upkeepID)
+			}
+
+			return nil
+		},
+	}
+}
+
+// triggerConfigFrom returns the trigger config from a hex argument or encodes a log trigger config from flags.
+func triggerConfigFrom(args []string) ([]byte, error) {
+	if len(args) > 0 {
+		if logTrigger.contract != "" {
+			return nil, fmt.Errorf("provide either a hex encoded trigger config or log filter flags")
+		}
+
+		triggerConfig, err := hexutil.Decode(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid trigger config: %w", err)
+		}
+
+		return triggerConfig, nil
+	}
+
+	if !common.IsHexAddress(logTrigger.contract) {
+		return nil, fmt.Errorf("provide a hex encoded trigger config or a log contract address with --log-contract")
+	}
+
+	conf := asset.LogTriggerConfig{
+		ContractAddress: common.HexToAddress(logTrigger.contract),
+		FilterSelector:  logTrigger.selector,
+	}
+
+	for idx, topic := range []*common.Hash{&conf.Topic0, &conf.Topic1, &conf.Topic2, &conf.Topic3} {
+		if logTrigger.topics[idx] != "" {
+			*topic = common.HexToHash(logTrigger.topics[idx])
+		}
+	}
+
+	return asset.EncodeLogTriggerConfig(conf)
+}
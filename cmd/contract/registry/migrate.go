@@ -0,0 +1,284 @@
+package registry
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/node"
+)
+
+var (
+	migrateTo        string
+	migrateBatchSize int
+	skipJobs         bool
+	leaveUnowned     bool
+
+	migrateCmd = &cobra.Command{
+		Use:   "migrate [UPKEEP_ID...]",
+		Short: "Migrate upkeeps to another registry",
+		Long: `Migrate upkeeps from the environment registry to another v2.1 registry given as an environment name or
+address. Each registry is permitted as a migration peer of the other, the upkeeps are moved in batches of
+migrateUpkeeps transactions, and balances and configs are verified on the destination. The environment registry and
+the bootstrap and automation jobs of all nodes are then pointed at the destination.
+
+All active upkeeps administered by the key are migrated when no upkeep ids are provided. The migration stops when other
+active upkeeps, such as those registered by a verifiable load contract, are not administered by the key unless
+--leave-unowned is set, in which case their ids are reported. The key must own both registries and the source
+registry must have a transcoder in its on-chain config, which can be deployed with deploy-transcoder.`,
+		Example: `To migrate all upkeeps to the registry of another environment:
+
+$ automation-cli contract registry migrate --to new.environment
+
+To migrate two upkeeps to a registry address without changing node jobs:
+
+$ automation-cli contract registry migrate --to 0x... 1234 5678 --skip-jobs`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, source, err := connectRegistry(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			destConf, err := migrationDestination(path, env, migrateTo)
+			if err != nil {
+				return err
+			}
+
+			destination := newRegistryV21(&env, destConf)
+			if _, err := destination.Connect(cmd.Context(), deployer); err != nil {
+				return err
+			}
+
+			if err := source.CheckMigrationSource(cmd.Context()); err != nil {
+				return err
+			}
+
+			before, unowned, err := migrationUpkeeps(cmd, deployer, source, args)
+			if err != nil {
+				return err
+			}
+
+			if len(unowned) > 0 && !leaveUnowned {
+				return fmt.Errorf("%w: %d active upkeeps are not administered by the key and would be left on the "+
+					"source registry: %s; set --leave-unowned to continue", asset.ErrMigration, len(unowned),
+					joinIDs(unowned))
+			}
+
+			if len(before) == 0 {
+				return fmt.Errorf("no upkeeps to migrate")
+			}
+
+			upkeepIDs := make([]*big.Int, len(before))
+			for idx, detail := range before {
+				upkeepIDs[idx] = detail.ID
+			}
+
+			if err := source.AllowMigration(
+				cmd.Context(), deployer, destination.Address(), asset.MigrationPermissionOutgoing,
+			); err != nil {
+				return err
+			}
+
+			if err := destination.AllowMigration(
+				cmd.Context(), deployer, source.Address(), asset.MigrationPermissionIncoming,
+			); err != nil {
+				return err
+			}
+
+			if err := source.MigrateUpkeeps(
+				cmd.Context(), deployer, destination.Address(), upkeepIDs, migrateBatchSize,
+			); err != nil {
+				return err
+			}
+
+			// nothing was moved in a dry run so the destination cannot be verified
+			if !io.DryRunFromContext(cmd.Context()) {
+				after, err := destination.Upkeeps(cmd.Context(), deployer, upkeepIDs)
+				if err != nil {
+					return err
+				}
+
+				// the environment and node jobs remain on the source registry until the migration is verified
+				if err := writeMigrationChecks(cmd, asset.CompareMigratedUpkeeps(before, after)); err != nil {
+					return err
+				}
+			}
+
+			previous := env.Registry.Address
+			env.Registry = destConf
+
+			if err := config.Write(path.MustWrite(config.EnvironmentConfigFilename), env); err != nil {
+				return err
+			}
+
+			if !skipJobs && (env.Bootstrap != nil || len(env.Participants) > 0) {
+				if err := node.RepointRegistryJobs(
+					cmd.Context(), previous, *env.Registry, env.Bootstrap, env.Participants,
+				); err != nil {
+					return err
+				}
+			}
+
+			if len(unowned) > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "upkeeps left on source registry %s: %s\n", previous, joinIDs(unowned))
+			}
+
+			return nil
+		},
+	}
+)
+
+// migrationDestination resolves the destination registry config from an environment name or a registry address. A
+// registry given by address takes on the config of the environment registry.
+func migrationDestination(
+	path io.Environment,
+	env config.Environment,
+	target string,
+) (*config.AutomationRegistryV21Contract, error) {
+	var destination config.AutomationRegistryV21Contract
+
+	switch {
+	case target == "":
+		return nil, fmt.Errorf("destination registry required")
+	case common.IsHexAddress(target):
+		destination = *env.Registry
+		destination.Address = common.HexToAddress(target).Hex()
+	default:
+		rootPath, err := path.Root.Path()
+		if err != nil {
+			return nil, err
+		}
+
+		// reading a missing environment would create it
+		if _, err := os.Stat(filepath.Join(rootPath, target, config.EnvironmentConfigFilename)); err != nil {
+			return nil, fmt.Errorf("environment '%s' not found", target)
+		}
+
+		other := io.Environment{Root: path.Root, Name: target, DryRun: path.DryRun}
+
+		otherEnv, err := config.ReadFrom(other.MustRead(config.EnvironmentConfigFilename))
+		if err != nil {
+			return nil, err
+		}
+
+		if otherEnv.Registry == nil || otherEnv.Registry.Address == "" {
+			return nil, fmt.Errorf("registry does not exist in environment '%s'", target)
+		}
+
+		destination = *otherEnv.Registry
+	}
+
+	if version := destination.ContractVersion(); version != config.RegistryVersion21 {
+		return nil, fmt.Errorf("%w: destination must be a v2.1 registry; found %s", asset.ErrUnsupportedVersion, version)
+	}
+
+	if strings.EqualFold(destination.Address, env.Registry.Address) {
+		return nil, fmt.Errorf("destination is the environment registry")
+	}
+
+	return &destination, nil
+}
+
+// migrationUpkeeps reads the upkeeps to migrate. Provided upkeeps must be administered by the key and not cancelled.
+// Without provided ids, all active upkeeps administered by the key are selected and the ids of active upkeeps that
+// are administered by another address are returned separately. Cancelled upkeeps are skipped.
+func migrationUpkeeps(
+	cmd *cobra.Command,
+	deployer *asset.Deployer,
+	registry *asset.RegistryV21Deployable,
+	args []string,
+) ([]asset.UpkeepDetail, []*big.Int, error) {
+	upkeepIDs := make([]*big.Int, 0, len(args))
+
+	for _, arg := range args {
+		upkeepID, err := parseUpkeepID(arg)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		upkeepIDs = append(upkeepIDs, upkeepID)
+	}
+
+	selectAll := len(upkeepIDs) == 0
+
+	if selectAll {
+		var err error
+
+		if upkeepIDs, err = registry.ActiveUpkeepIDs(cmd.Context(), deployer); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	details, err := registry.Upkeeps(cmd.Context(), deployer, upkeepIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	selected := make([]asset.UpkeepDetail, 0, len(details))
+	unowned := make([]*big.Int, 0)
+
+	for _, detail := range details {
+		switch {
+		case detail.Admin == deployer.Address && !detail.Cancelled:
+			selected = append(selected, detail)
+		case !selectAll:
+			return nil, nil, fmt.Errorf("upkeep %s is not administered by the key or is cancelled", detail.ID)
+		case detail.Cancelled:
+			fmt.Fprintf(cmd.OutOrStdout(), "skipping cancelled upkeep %s\n", detail.ID)
+		default:
+			unowned = append(unowned, detail.ID)
+		}
+	}
+
+	return selected, unowned, nil
+}
+
+func joinIDs(upkeepIDs []*big.Int) string {
+	ids := make([]string, len(upkeepIDs))
+	for idx, upkeepID := range upkeepIDs {
+		ids[idx] = upkeepID.String()
+	}
+
+	return strings.Join(ids, ", ")
+}
+
+func writeMigrationChecks(cmd *cobra.Command, checks []asset.MigrationCheck) error {
+	writer := table.NewWriter()
+	writer.SetStyle(table.StyleLight)
+	writer.AppendHeader(table.Row{"ID", "Source Balance", "Destination Balance", "Check"})
+
+	var failed int
+
+	for _, check := range checks {
+		status := "ok"
+
+		if len(check.Mismatches) > 0 {
+			status = mismatch + ": " + strings.Join(check.Mismatches, ", ")
+			failed++
+		}
+
+		writer.AppendRow(table.Row{check.ID, check.SourceBalance, check.DestinationBalance, status})
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), writer.Render())
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d upkeeps do not match on the destination", asset.ErrMigration, failed, len(checks))
+	}
+
+	return nil
+}
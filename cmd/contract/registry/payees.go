@@ -0,0 +1,247 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
+)
+
+var (
+	payee       string
+	paymentTo   string
+	paymentNode int
+	payeeNodes  []int
+
+	setPayeesCmd = &cobra.Command{
+		Use:   "set-payees",
+		Short: "Set the payee for participant transmitters",
+		Long: `Set the payee that collects the LINK accrued by each participant transmitter in the registry config. The
+payee is a key alias or an address and defaults to the key address. Payees can only be set once per transmitter.`,
+		Example: `To make the key aliased 'payments' the payee of all participants:
+
+$ automation-cli contract registry set-payees --payee payments
+
+To set the payee for the first two participants only:
+
+$ automation-cli contract registry set-payees --payee 0x... --node 0 --node 1`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, registry, err := connectRegistry(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			payeeAddr, err := resolvePayee(cmd, payee, deployer.Address)
+			if err != nil {
+				return err
+			}
+
+			nodes, err := selectParticipants(env, payeeNodes)
+			if err != nil {
+				return err
+			}
+
+			payees := make(map[common.Address]common.Address, len(nodes))
+
+			for _, participant := range nodes {
+				payees[common.HexToAddress(participant.Address)] = payeeAddr
+			}
+
+			if err := registry.SetPayees(cmd.Context(), deployer, payees); err != nil {
+				return err
+			}
+
+			if !io.DryRunFromContext(cmd.Context()) {
+				fmt.Fprintf(cmd.OutOrStdout(), "payee %s set for %d transmitters\n", payeeAddr, len(payees))
+			}
+
+			return nil
+		},
+	}
+
+	withdrawPaymentCmd = &cobra.Command{
+		Use:   "withdraw-payment",
+		Short: "Withdraw the LINK accrued by a participant transmitter",
+		Long: `Withdraw the LINK accrued by the transmitter of a participant node. The key must be the payee of the
+transmitter and funds are sent to the key address unless --to is provided.`,
+		Example: `$ automation-cli contract registry withdraw-payment --node 0 --key payments`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			nodes, err := selectParticipants(env, []int{paymentNode})
+			if err != nil {
+				return err
+			}
+
+			deployer, registry, err := connectRegistry(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			to := deployer.Address
+			if paymentTo != "" {
+				if !common.IsHexAddress(paymentTo) {
+					return fmt.Errorf("invalid address '%s'", paymentTo)
+				}
+
+				to = common.HexToAddress(paymentTo)
+			}
+
+			transmitter := common.HexToAddress(nodes[0].Address)
+
+			payments, err := registry.Payments(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			for _, payment := range payments {
+				if payment.Transmitter != transmitter {
+					continue
+				}
+
+				if payment.Payee != deployer.Address {
+					return fmt.Errorf("%w: key %s is not the payee of transmitter %s", asset.ErrPayee,
+						deployer.Address, transmitter)
+				}
+
+				if err := registry.WithdrawPayment(cmd.Context(), deployer, transmitter, to); err != nil {
+					return err
+				}
+
+				if !io.DryRunFromContext(cmd.Context()) {
+					fmt.Fprintf(cmd.OutOrStdout(), "withdrew %s juels for %s to %s\n", payment.Balance, nodes[0].Name, to)
+				}
+
+				return nil
+			}
+
+			return fmt.Errorf("%w: transmitter %s is not in the registry config", asset.ErrPayee, transmitter)
+		},
+	}
+
+	ownerWithdrawCmd = &cobra.Command{
+		Use:   "owner-withdraw",
+		Short: "Withdraw the owner LINK balance of the registry",
+		Long:  `Withdraw the LINK collected by the registry as owner premium to the key. The key must own the registry.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, registry, err := connectRegistry(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			state, err := registry.State(cmd.Context(), deployer)
+			if err != nil {
+				return err
+			}
+
+			if err := registry.WithdrawOwnerFunds(cmd.Context(), deployer); err != nil {
+				return err
+			}
+
+			if !io.DryRunFromContext(cmd.Context()) {
+				fmt.Fprintf(cmd.OutOrStdout(), "withdrew %s juels to %s\n", state.OwnerLinkBalance, deployer.Address)
+			}
+
+			return nil
+		},
+	}
+
+	recoverFundsCmd = &cobra.Command{
+		Use:   "recover-funds",
+		Short: "Recover LINK sent to the registry outside of upkeep funding",
+		Long: `Send the LINK held by the registry in excess of its expected balance to the key. The key must own the
+registry.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, registry, err := connectRegistry(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			if err := registry.RecoverFunds(cmd.Context(), deployer); err != nil {
+				return err
+			}
+
+			if !io.DryRunFromContext(cmd.Context()) {
+				fmt.Fprintf(cmd.OutOrStdout(), "recovered excess LINK to %s\n", deployer.Address)
+			}
+
+			return nil
+		},
+	}
+)
+
+// resolvePayee returns the address for a key alias or hex address. An empty value resolves to the default address.
+func resolvePayee(cmd *cobra.Command, value string, defaultAddr common.Address) (common.Address, error) {
+	switch {
+	case value == "":
+		return defaultAddr, nil
+	case common.IsHexAddress(value):
+		return common.HexToAddress(value), nil
+	}
+
+	keys, err := keystore.Read(cmd.Context())
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	key, err := keys.KeyForAlias(value)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	if !common.IsHexAddress(key.Address) {
+		return common.Address{}, fmt.Errorf("key '%s' has no address", value)
+	}
+
+	return common.HexToAddress(key.Address), nil
+}
+
+// selectParticipants returns the participants at the provided indexes or all participants when none are provided.
+func selectParticipants(env config.Environment, indexes []int) ([]config.NodeConfig, error) {
+	if len(env.Participants) == 0 {
+		return nil, fmt.Errorf("no participants in environment")
+	}
+
+	if len(indexes) == 0 {
+		return env.Participants, nil
+	}
+
+	selected := make([]config.NodeConfig, 0, len(indexes))
+
+	for _, idx := range indexes {
+		if idx < 0 || idx >= len(env.Participants) {
+			return nil, fmt.Errorf("participant %d does not exist; environment has %d", idx, len(env.Participants))
+		}
+
+		selected = append(selected, env.Participants[idx])
+	}
+
+	return selected, nil
+}
@@ -1,32 +1,76 @@
 package registry
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
 
 //nolint:gochecknoinits
 func init() {
 	RootCmd.AddCommand(deployCmd)
 	RootCmd.AddCommand(setCmd)
 	RootCmd.AddCommand(setConfigCmd)
+	RootCmd.AddCommand(showConfigCmd)
+	RootCmd.AddCommand(migrateCmd)
+	RootCmd.AddCommand(deployTranscoderCmd)
+	RootCmd.AddCommand(setPayeesCmd)
+	RootCmd.AddCommand(withdrawPaymentCmd)
+	RootCmd.AddCommand(ownerWithdrawCmd)
+	RootCmd.AddCommand(recoverFundsCmd)
+	RootCmd.AddCommand(upkeepCmd)
 
 	deployCmd.Flags().
 		StringVar(&mode, "mode", "DEFAULT", "registry mode (applies to v2.x; valid options are DEFAULT, ARBITRUM, OPTIMISM)")
 
+	for _, cmd := range []*cobra.Command{deployCmd, setCmd} {
+		cmd.Flags().StringVar(&registryVersion, "version", config.RegistryVersion21, "registry version (v2.0, v2.1)")
+	}
+
+	setConfigCmd.Flags().
+		StringVar(&configPath, "from", "", "apply onchain, offchain, and ocrnetwork values from a json or toml file")
+
 	setConfigCmd.Flags().
-		StringVar(&ocrConfigPath, "with-ocr-config", "", "override stored config with values from a json file")
+		StringVar(&configPath, "with-ocr-config", "", "apply config values from a json file")
+	_ = setConfigCmd.Flags().MarkDeprecated("with-ocr-config", "use --from instead")
+
+	setConfigCmd.Flags().
+		BoolVarP(&assumeYes, "yes", "y", false, "send the config without asking for confirmation")
+
+	showConfigCmd.Flags().StringVar(&outputFormat, "format", "text", "output format (text, json)")
+
+	migrateCmd.Flags().StringVar(&migrateTo, "to", "", "destination registry as an environment name or address")
+	migrateCmd.Flags().
+		IntVar(&migrateBatchSize, "batch-size", asset.DefaultMigrationBatchSize, "upkeeps migrated per transaction")
+	migrateCmd.Flags().BoolVar(&skipJobs, "skip-jobs", false, "leave node jobs pointed at the source registry")
+	migrateCmd.Flags().
+		BoolVar(&leaveUnowned, "leave-unowned", false, "migrate when active upkeeps are administered by other addresses")
+	_ = migrateCmd.MarkFlagRequired("to")
+
+	setPayeesCmd.Flags().StringVar(&payee, "payee", "", "payee key alias or address (default is the key address)")
+	setPayeesCmd.Flags().IntSliceVar(&payeeNodes, "node", nil, "participant index to set the payee for (default all)")
+
+	withdrawPaymentCmd.Flags().IntVar(&paymentNode, "node", 0, "participant index to withdraw the payment for")
+	withdrawPaymentCmd.Flags().StringVar(&paymentTo, "to", "", "address to receive funds (default is the key address)")
+	_ = withdrawPaymentCmd.MarkFlagRequired("node")
 
 	setConfigCmd.Flags().
 		Uint8Var(&maxFaulty, "max-faulty", 1, "set max faulty nodes (default 1)")
 }
 
 var (
-	mode          string
-	ocrConfigPath string
-	maxFaulty     uint8
+	mode            string
+	registryVersion string
+	configPath      string
+	maxFaulty       uint8
+	assumeYes       bool
 
 	RootCmd = &cobra.Command{
 		Use:   "registry [ACTION]",
 		Short: "Create and interact with a registry contract",
-		Long:  `Create a registry contract, connect to an existing registry, or run a set-config.`,
-		Args:  cobra.MinimumNArgs(1),
+		Long: `Create a registry contract, connect to an existing registry, run a set-config, inspect upkeeps, migrate
+upkeeps to another registry, or collect transmitter and owner LINK.`,
+		Args: cobra.MinimumNArgs(1),
 	}
 )
@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 
+	"github.com/easterthebunny/automation-cli/internal/asset"
 	"github.com/easterthebunny/automation-cli/internal/config"
 	"github.com/easterthebunny/automation-cli/internal/io"
 )
@@ -35,13 +36,20 @@ var (
 				return fmt.Errorf("provided address must be hex encoded")
 			}
 
+			if err := asset.CheckRegistryVersion(registryVersion); err != nil {
+				return err
+			}
+
 			if env.Registry == nil {
 				env.Registry = &config.AutomationRegistryV21Contract{
-					Type:    config.AutomationRegistryContractType,
-					Version: "v2.1",
+					Type: config.AutomationRegistryContractType,
 				}
 			}
 
+			if env.Registry.Version == "" || cmd.Flags().Changed("version") {
+				env.Registry.Version = registryVersion
+			}
+
 			env.Registry.Address = args[0]
 
 			return config.Write(path.MustWrite(config.EnvironmentConfigFilename), env)
@@ -0,0 +1,141 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+)
+
+const mismatch = "MISMATCH"
+
+var showConfigCmd = &cobra.Command{
+	Use:   "show-config",
+	Short: "Print the live OCR3 configuration of the registry",
+	Long: `Print the config digest and details of the latest registry config from the ConfigSet event, the decoded
+OCR3 offchain config and ocr2keepers plugin config, and each oracle signer and transmitter. Oracles are matched to
+environment participants by transmitter address and signers are checked against the participant onchain public key.
+Mismatches and participants missing from the on-chain config are flagged.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		_, env, key, err := prepare(cmd)
+		if err != nil {
+			return err
+		}
+
+		_, registry, err := connectRegistry(cmd, &env, key)
+		if err != nil {
+			return err
+		}
+
+		latest, err := registry.LatestConfig(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if latest.BlockNumber == 0 {
+			return fmt.Errorf("registry has not been configured")
+		}
+
+		checks, missing := asset.CheckOracles(latest.Oracles, env.Participants)
+
+		if outputFormat != "text" {
+			return writeJSON(cmd, struct {
+				asset.RegistryConfig
+				Checks  []asset.OracleCheck `json:"checks"`
+				Missing []string            `json:"missingParticipants"`
+			}{RegistryConfig: latest, Checks: checks, Missing: missing})
+		}
+
+		out := cmd.OutOrStdout()
+
+		fmt.Fprintln(out, configSummaryTable(latest))
+		fmt.Fprintln(out, ocrNetworkTable(latest))
+		fmt.Fprintln(out, "Plugin Config")
+		fmt.Fprintln(out, indentJSON(latest.PluginConfig))
+		fmt.Fprintln(out, oracleTable(checks))
+
+		for _, name := range missing {
+			fmt.Fprintf(out, "%s: participant %s is not in the on-chain config\n", mismatch, name)
+		}
+
+		return nil
+	},
+}
+
+func configSummaryTable(latest asset.RegistryConfig) string {
+	writer := table.NewWriter()
+	writer.SetStyle(table.StyleLight)
+	writer.AppendRows([]table.Row{
+		{"Config Digest", latest.ConfigDigest.Hex()},
+		{"Config Count", latest.ConfigCount},
+		{"Block", latest.BlockNumber},
+		{"Transaction", latest.TxHash.Hex()},
+		{"Offchain Config Version", latest.OffchainConfigVersion},
+		{"Oracles (N)", len(latest.Oracles)},
+		{"Max Faulty (F)", latest.Values.OCRNetwork.MaxFaultyNodes},
+	})
+
+	return writer.Render()
+}
+
+func ocrNetworkTable(latest asset.RegistryConfig) string {
+	ocr := latest.Values.OCRNetwork
+
+	writer := table.NewWriter()
+	writer.SetStyle(table.StyleLight)
+	writer.AppendHeader(table.Row{"OCR3 Config", "Value"})
+	writer.AppendRows([]table.Row{
+		{"Delta Progress", ocr.DeltaProgress},
+		{"Delta Resend", ocr.DeltaResend},
+		{"Delta Initial", ocr.DeltaInitial},
+		{"Delta Round", ocr.DeltaRound},
+		{"Delta Grace", ocr.DeltaGrace},
+		{"Delta Certified Commit Request", ocr.DeltaCertifiedCommitRequest},
+		{"Delta Stage", ocr.DeltaStage},
+		{"Max Rounds", ocr.MaxRounds},
+		{"Max Duration Query", ocr.MaxDurationQuery},
+		{"Max Duration Observation", ocr.MaxDurationObservation},
+		{"Max Duration Accept Finalized Report", ocr.MaxDurationShouldAcceptFinalizedReport},
+		{"Max Duration Transmit Accepted Report", ocr.MaxDurationShouldTransmitAcceptedReport},
+	})
+
+	return writer.Render()
+}
+
+func oracleTable(checks []asset.OracleCheck) string {
+	writer := table.NewWriter()
+	writer.SetStyle(table.StyleLight)
+	writer.AppendHeader(table.Row{"#", "Participant", "Signer", "Transmitter", "Peer ID", "Check"})
+
+	for idx, check := range checks {
+		participant, status := check.Participant, "ok"
+
+		switch {
+		case !check.TransmitterMatch:
+			participant, status = "-", mismatch+": unknown transmitter"
+		case !check.SignerMatch:
+			status = mismatch + ": signer"
+		}
+
+		writer.AppendRow(table.Row{
+			idx, participant, check.Signer.Hex(), check.Transmitter.Hex(), check.PeerID, status,
+		})
+	}
+
+	return writer.Render()
+}
+
+func indentJSON(raw []byte) string {
+	var buf bytes.Buffer
+
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return string(raw)
+	}
+
+	return buf.String()
+}
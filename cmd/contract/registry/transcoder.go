@@ -0,0 +1,51 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/io"
+)
+
+var deployTranscoderCmd = &cobra.Command{
+	Use:   "deploy-transcoder",
+	Short: "Deploy an upkeep transcoder for migrations",
+	Long: `Deploy an upkeep transcoder and save the address as the transcoder in the registry on-chain config. The
+transcoder is only used by the registry after the next set-config and is required to migrate upkeeps out of the
+registry.`,
+	Example: `To prepare the environment registry for a migration:
+
+$ automation-cli contract registry deploy-transcoder
+$ automation-cli contract registry set-config`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, env, key, err := prepare(cmd)
+		if err != nil {
+			return err
+		}
+
+		if env.Registry == nil {
+			return fmt.Errorf("registry does not exist")
+		}
+
+		deployer, err := asset.NewDeployer(&env, key)
+		if err != nil {
+			return err
+		}
+
+		addr, err := asset.DeployUpkeepTranscoder(cmd.Context(), deployer)
+		if err != nil {
+			return err
+		}
+
+		env.Registry.Onchain.Transcoder = addr.Hex()
+
+		if !io.DryRunFromContext(cmd.Context()) {
+			fmt.Fprintf(cmd.OutOrStdout(), "transcoder deployed at %s; run set-config to apply it\n", addr)
+		}
+
+		return config.Write(path.MustWrite(config.EnvironmentConfigFilename), env)
+	},
+}
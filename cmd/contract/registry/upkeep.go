@@ -0,0 +1,305 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+func init() {
+	upkeepCmd.AddCommand(upkeepGetCmd)
+	upkeepCmd.AddCommand(upkeepListCmd)
+	upkeepCmd.AddCommand(upkeepStateCmd)
+
+	upkeepCmd.PersistentFlags().StringVar(&outputFormat, "format", "text", "output format (text, json)")
+}
+
+var (
+	outputFormat string
+
+	upkeepCmd = &cobra.Command{
+		Use:   "upkeep [ACTION]",
+		Short: "Inspect and manage upkeeps on the registry",
+		Long: `Inspect upkeeps registered on the environment registry and the registry wide state, and manage single
+upkeeps as their admin.`,
+		Example: `To print the details of a single upkeep:
+
+$ automation-cli contract registry upkeep get 1234
+
+To fund an upkeep with 5 LINK and raise its gas limit:
+
+$ automation-cli contract registry upkeep add-funds 1234 5e18
+$ automation-cli contract registry upkeep set-gas-limit 1234 1000000
+
+To list all active upkeeps as JSON:
+
+$ automation-cli contract registry upkeep list --format=json`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	upkeepGetCmd = &cobra.Command{
+		Use:   "get [UPKEEP_ID]",
+		Short: "Print the registry state of an upkeep",
+		Long: `Print the balance, admin, target, trigger type and config, gas limit, paused and cancelled state, last
+perform block, and min balance of an upkeep.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			upkeepID, err := parseUpkeepID(args[0])
+			if err != nil {
+				return err
+			}
+
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, registry, err := connectRegistry(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			detail, err := registry.Upkeep(cmd.Context(), deployer, upkeepID)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat != "text" {
+				return writeJSON(cmd, detail)
+			}
+
+			writer := table.NewWriter()
+
+			writer.AppendRows([]table.Row{
+				{"ID", detail.ID},
+				{"Target", detail.Target},
+				{"Admin", detail.Admin},
+				{"Forwarder", detail.Forwarder},
+				{"Balance", detail.Balance},
+				{"Min Balance", detail.MinBalance},
+				{"Amount Spent", detail.AmountSpent},
+				{"Trigger Type", detail.TriggerType},
+				{"Trigger Config", detail.TriggerConfig},
+				{"Gas Limit", detail.GasLimit},
+				{"Check Data", detail.CheckData},
+				{"Offchain Config", detail.OffchainConfig},
+				{"Paused", detail.Paused},
+				{"Cancelled", detail.Cancelled},
+				{"Last Perform Block", detail.LastPerformedBlock},
+			})
+
+			if detail.Cancelled {
+				writer.AppendRow(table.Row{"Max Valid Block", detail.MaxValidBlock})
+			}
+
+			if conf := detail.LogTriggerConfig; conf != nil {
+				writer.AppendRows([]table.Row{
+					{"Log Contract", conf.ContractAddress},
+					{"Log Filter Selector", conf.FilterSelector},
+					{"Log Topic 0", conf.Topic0},
+					{"Log Topic 1", conf.Topic1},
+					{"Log Topic 2", conf.Topic2},
+					{"Log Topic 3", conf.Topic3},
+				})
+			}
+
+			writer.SetStyle(table.StyleLight)
+
+			fmt.Fprintln(cmd.OutOrStdout(), writer.Render())
+
+			return nil
+		},
+	}
+
+	upkeepListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all active upkeeps on the registry",
+		Long:  `List all active upkeeps on the registry with their trigger type, balance, admin, and paused state.`,
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, registry, err := connectRegistry(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			upkeepIDs, err := registry.ActiveUpkeepIDs(cmd.Context(), deployer)
+			if err != nil {
+				return err
+			}
+
+			details, err := registry.Upkeeps(cmd.Context(), deployer, upkeepIDs)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat != "text" {
+				return writeJSON(cmd, details)
+			}
+
+			writer := table.NewWriter()
+
+			writer.AppendHeader(table.Row{
+				"ID", "Trigger", "Target", "Admin", "Balance", "Min Balance", "Gas Limit", "Paused", "Last Perform"})
+
+			for _, detail := range details {
+				writer.AppendRow(table.Row{
+					detail.ID, detail.TriggerType, detail.Target, detail.Admin, detail.Balance, detail.MinBalance,
+					detail.GasLimit, detail.Paused, detail.LastPerformedBlock,
+				})
+			}
+
+			writer.SetStyle(table.StyleLight)
+
+			fmt.Fprintln(cmd.OutOrStdout(), writer.Render())
+
+			return nil
+		},
+	}
+
+	upkeepStateCmd = &cobra.Command{
+		Use:   "state",
+		Short: "Print the registry wide state and totals",
+		Long: `Print the registry owner, paused state, upkeep count, LINK balances and premium totals, latest config, and
+the current signers and transmitters.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, registry, err := connectRegistry(cmd, &env, key)
+			if err != nil {
+				return err
+			}
+
+			state, err := registry.State(cmd.Context(), deployer)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat != "text" {
+				return writeJSON(cmd, state)
+			}
+
+			writer := table.NewWriter()
+
+			writer.AppendRows([]table.Row{
+				{"Address", state.Address},
+				{"Type and Version", state.TypeAndVersion},
+				{"Owner", state.Owner},
+				{"Paused", state.Paused},
+				{"Upkeeps", state.NumUpkeeps},
+				{"Owner LINK Balance", state.OwnerLinkBalance},
+				{"Expected LINK Balance", state.ExpectedLinkBalance},
+				{"Total Premium", state.TotalPremium},
+				{"Nonce", state.Nonce},
+				{"Config Count", state.ConfigCount},
+				{"Latest Config Block", state.LatestConfigBlock},
+				{"Latest Config Digest", state.LatestConfigDigest},
+				{"Latest Epoch", state.LatestEpoch},
+				{"F", state.F},
+			})
+
+			for idx, signer := range state.Signers {
+				writer.AppendRow(table.Row{fmt.Sprintf("Signer %d", idx), signer})
+			}
+
+			for idx, transmitter := range state.Transmitters {
+				writer.AppendRow(table.Row{fmt.Sprintf("Transmitter %d", idx), transmitter})
+			}
+
+			writer.SetStyle(table.StyleLight)
+
+			fmt.Fprintln(cmd.OutOrStdout(), writer.Render())
+
+			return nil
+		},
+	}
+)
+
+// connectRegistry creates a deployer and connects to the registry in the environment.
+func connectRegistry(
+	cmd *cobra.Command,
+	env *config.Environment,
+	key config.Key,
+) (*asset.Deployer, *asset.RegistryV21Deployable, error) {
+	if env.Registry == nil || env.Registry.Address == "" {
+		return nil, nil, fmt.Errorf("registry does not exist")
+	}
+
+	if version := env.Registry.ContractVersion(); version != config.RegistryVersion21 {
+		return nil, nil, fmt.Errorf("%w: command requires a v2.1 registry; found %s", asset.ErrUnsupportedVersion, version)
+	}
+
+	deployer, err := asset.NewDeployer(env, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	registry := newRegistryV21(env, env.Registry)
+
+	if _, err := registry.Connect(cmd.Context(), deployer); err != nil {
+		return nil, nil, err
+	}
+
+	return deployer, registry, nil
+}
+
+// newRegistryV21 creates a v2.1 registry deployable for the registry config where the environment token and feeds
+// are optional.
+func newRegistryV21(
+	env *config.Environment,
+	rCfg *config.AutomationRegistryV21Contract,
+) *asset.RegistryV21Deployable {
+	var (
+		link     config.LinkTokenContract
+		linkFeed config.FeedContract
+		gasFeed  config.FeedContract
+	)
+
+	if env.LinkToken != nil {
+		link = *env.LinkToken
+	}
+
+	if env.LinkETH != nil {
+		linkFeed = *env.LinkETH
+	}
+
+	if env.FastGas != nil {
+		gasFeed = *env.FastGas
+	}
+
+	return asset.NewRegistryV21Deployable(link, linkFeed, gasFeed, rCfg)
+}
+
+func parseUpkeepID(value string) (*big.Int, error) {
+	upkeepID, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid upkeep id '%s'", value)
+	}
+
+	return upkeepID, nil
+}
+
+func writeJSON(cmd *cobra.Command, value any) error {
+	if outputFormat != "json" {
+		return fmt.Errorf("unknown format '%s'", outputFormat)
+	}
+
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
@@ -0,0 +1,295 @@
+package down
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
+	"github.com/easterthebunny/automation-cli/internal/node"
+)
+
+func init() {
+	RootCmd.Flags().BoolVar(
+		&keepContracts, "keep-contracts", false,
+		"leave upkeeps, LINK balances, and contract state untouched")
+}
+
+var (
+	keepContracts bool
+	dryRun        bool
+
+	RootCmd = &cobra.Command{
+		Use:   "down",
+		Short: "Tear down an automation environment",
+		Long: `Tear down an automation environment. All upkeeps on both verifiable load contracts are cancelled and the
+remaining LINK is withdrawn to the deployer. The bootstrap and participant node containers are removed along with their
+Postgres containers and volumes, the docker network for the group is deleted, and node secrets are pruned from the
+environment directory. The token, feed, registry, and registrar contracts remain in the environment.
+
+Cancelled upkeeps are saved to the environment until their LINK is withdrawn such that a failed withdrawal is retried
+by the next run.`,
+		Example: `To remove everything from an environment:
+
+$ automation-cli down --environment="geth.local"
+
+To remove only the nodes and keep contracts and upkeeps as they are:
+
+$ automation-cli down --keep-contracts --environment="geth.local"
+
+To print what would be removed without changing anything:
+
+$ automation-cli down --dry-run --environment="geth.local"`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			dryRun = io.DryRunFromContext(cmd.Context())
+
+			path, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			if !keepContracts {
+				deployer, err := asset.NewDeployer(&env, key)
+				if err != nil {
+					return err
+				}
+
+				if err := teardownLoadContracts(cmd, path, &env, deployer); err != nil {
+					return err
+				}
+			}
+
+			if err := teardownNodes(cmd, path, &env); err != nil {
+				return err
+			}
+
+			if dryRun {
+				return nil
+			}
+
+			env.CompletedSteps = nil
+
+			return config.Write(path.MustWrite(config.EnvironmentConfigFilename), env)
+		},
+	}
+)
+
+type loadTeardown interface {
+	ActiveUpkeepIDs(context.Context, *asset.Deployer) ([]*big.Int, error)
+	CancelUpkeeps(context.Context, *asset.Deployer, asset.VerifiableLoadInteractionConfig) error
+	WithdrawLINK(context.Context, *asset.Deployer, []*big.Int) error
+}
+
+// teardownLoadContracts cancels the upkeeps of each load contract and withdraws the LINK. The cancelled upkeeps are
+// saved before the withdrawal since they are no longer listed as active, and each load contract is removed from the
+// environment once its LINK is withdrawn.
+func teardownLoadContracts(
+	cmd *cobra.Command,
+	path io.Environment,
+	env *config.Environment,
+	deployer *asset.Deployer,
+) error {
+	var registrar config.AutomationRegistrarV21Contract
+
+	if env.Registrar != nil {
+		registrar = *env.Registrar
+	}
+
+	for _, loadRef := range []**config.VerifiableLoadContract{&env.ConditionalLoad, &env.LogLoad} {
+		load := *loadRef
+		if load == nil || load.Address == "" {
+			continue
+		}
+
+		var (
+			contract loadTeardown
+			err      error
+		)
+
+		switch load.LoadType {
+		case config.ConditionalLoad:
+			contract, err = asset.NewVerifiableLoadConditionalDeployable(registrar, load)
+		case config.LogTriggerLoad:
+			contract, err = asset.NewVerifiableLoadLogTriggerDeployable(registrar, load)
+		default:
+			err = fmt.Errorf("unknown verifiable load type '%s'", load.LoadType)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if env.LinkToken == nil {
+			return fmt.Errorf("link token required to withdraw LINK from load contracts")
+		}
+
+		upkeepIDs, err := contract.ActiveUpkeepIDs(cmd.Context(), deployer)
+		if err != nil {
+			return err
+		}
+
+		cancelled, err := parseUpkeepIDs(load.CancelledUpkeeps)
+		if err != nil {
+			return err
+		}
+
+		balance, err := deployer.BalanceLINK(cmd.Context(), load.Address)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(
+			cmd.OutOrStdout(),
+			"%s load contract (%s): cancel %d upkeeps and withdraw %s juels plus %d upkeep balances to %s\n",
+			load.LoadType, load.Address, len(upkeepIDs), balance, len(cancelled)+len(upkeepIDs), deployer.Address)
+
+		if dryRun {
+			continue
+		}
+
+		if len(upkeepIDs) > 0 {
+			if err := contract.CancelUpkeeps(cmd.Context(), deployer, asset.VerifiableLoadInteractionConfig{}); err != nil {
+				return err
+			}
+
+			cancelled = append(cancelled, upkeepIDs...)
+
+			for _, upkeepID := range upkeepIDs {
+				load.CancelledUpkeeps = append(load.CancelledUpkeeps, upkeepID.String())
+			}
+
+			if err := config.Write(path.MustWrite(config.EnvironmentConfigFilename), *env); err != nil {
+				return err
+			}
+		}
+
+		if err := contract.WithdrawLINK(cmd.Context(), deployer, cancelled); err != nil {
+			return err
+		}
+
+		*loadRef = nil
+
+		if err := config.Write(path.MustWrite(config.EnvironmentConfigFilename), *env); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseUpkeepIDs(values []string) ([]*big.Int, error) {
+	upkeepIDs := make([]*big.Int, 0, len(values))
+
+	for _, value := range values {
+		upkeepID, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid cancelled upkeep id '%s'", value)
+		}
+
+		upkeepIDs = append(upkeepIDs, upkeepID)
+	}
+
+	return upkeepIDs, nil
+}
+
+func teardownNodes(cmd *cobra.Command, path io.Environment, env *config.Environment) error {
+	basePath, err := path.Path()
+	if err != nil {
+		return err
+	}
+
+	// remove participants from the end such that a partial teardown leaves a consistent environment
+	for idx := len(env.Participants) - 1; idx >= 0; idx-- {
+		nodeConf := env.Participants[idx]
+
+		fmt.Fprintf(cmd.OutOrStdout(), "remove node %s-%s with postgres and secrets\n", env.Groupname, nodeConf.Name)
+
+		if dryRun {
+			continue
+		}
+
+		if err := node.RemoveParticipantNode(cmd.Context(), env.Groupname, nodeConf); err != nil {
+			return err
+		}
+
+		if err := node.RemoveNodeSecrets(fmt.Sprintf("%s/%s", basePath, nodeConf.Name)); err != nil {
+			return err
+		}
+
+		env.Participants = env.Participants[:idx]
+
+		if err := config.Write(path.MustWrite(config.EnvironmentConfigFilename), *env); err != nil {
+			return err
+		}
+	}
+
+	if env.Bootstrap != nil {
+		fmt.Fprintf(
+			cmd.OutOrStdout(), "remove node %s-%s with postgres and secrets\n", env.Groupname, env.Bootstrap.Name)
+
+		if !dryRun {
+			if err := node.RemoveParticipantNode(cmd.Context(), env.Groupname, *env.Bootstrap); err != nil {
+				return err
+			}
+
+			if err := node.RemoveNodeSecrets(fmt.Sprintf("%s/%s", basePath, env.Bootstrap.Name)); err != nil {
+				return err
+			}
+
+			env.Bootstrap = nil
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "remove docker network %s-local\n", env.Groupname)
+
+	if dryRun {
+		return nil
+	}
+
+	return node.RemoveNetwork(cmd.Context(), env.Groupname)
+}
+
+func prepare(cmd *cobra.Command) (io.Environment, config.Environment, config.Key, error) {
+	var (
+		env config.Environment
+		key config.Key
+		err error
+	)
+
+	path := io.EnvironmentFromContext(cmd.Context())
+	if path == nil {
+		return io.Environment{}, env, key, fmt.Errorf("environment not found")
+	}
+
+	env, err = config.ReadFrom(path.MustRead(config.EnvironmentConfigFilename))
+	if err != nil {
+		return io.Environment{}, env, key, err
+	}
+
+	keys, err := keystore.Read(cmd.Context())
+	if err != nil {
+		return io.Environment{}, env, key, err
+	}
+
+	pkOverride, err := cmd.Flags().GetString("key")
+	if err != nil {
+		return io.Environment{}, env, key, err
+	}
+
+	if pkOverride == "" {
+		pkOverride = env.PrivateKeyAlias
+	}
+
+	key, err = keys.KeyForAlias(pkOverride)
+	if err != nil {
+		return io.Environment{}, env, key, err
+	}
+
+	return *path, env, key, nil
+}
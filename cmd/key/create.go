@@ -9,6 +9,7 @@ import (
 
 	"github.com/easterthebunny/automation-cli/internal/config"
 	cliio "github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
 )
 
 var (
@@ -30,7 +31,7 @@ var (
 				return fmt.Errorf("environment not found")
 			}
 
-			conf, err := config.ReadPrivateKeysFrom(env.Root.MustRead(config.PrivateKeyConfigFilename))
+			conf, err := keystore.Read(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -41,7 +42,7 @@ var (
 				Address: crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
 			})
 
-			return config.WritePrivateKeys(env.Root.MustWrite(config.PrivateKeyConfigFilename), conf)
+			return keystore.Write(cmd.Context(), conf)
 		},
 	}
 )
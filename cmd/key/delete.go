@@ -6,8 +6,8 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/easterthebunny/automation-cli/internal/config"
 	cliio "github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
 )
 
 var deleteCmd = &cobra.Command{
@@ -22,7 +22,7 @@ var deleteCmd = &cobra.Command{
 			return fmt.Errorf("environment not found")
 		}
 
-		conf, err := config.ReadPrivateKeysFrom(env.Root.MustRead(config.PrivateKeyConfigFilename))
+		conf, err := keystore.Read(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -40,7 +40,7 @@ var deleteCmd = &cobra.Command{
 
 				conf.Keys = append(conf.Keys[:idx], conf.Keys[idx+1:]...)
 
-				return config.WritePrivateKeys(env.Root.MustWrite(config.PrivateKeyConfigFilename), conf)
+				return keystore.Write(cmd.Context(), conf)
 			}
 		}
 
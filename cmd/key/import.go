@@ -9,13 +9,14 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/keystore"
+	gethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 
 	"github.com/easterthebunny/automation-cli/internal/config"
 	cliio "github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
 )
 
 type GanacheAddresses struct {
@@ -72,7 +73,7 @@ var (
 				return fmt.Errorf("environment not found")
 			}
 
-			conf, err := config.ReadPrivateKeysFrom(env.Root.MustRead(config.PrivateKeyConfigFilename))
+			conf, err := keystore.Read(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -94,7 +95,7 @@ var (
 
 			conf.Keys = append(conf.Keys, toAdd...)
 
-			return config.WritePrivateKeys(env.Root.MustWrite(config.PrivateKeyConfigFilename), conf)
+			return keystore.Write(cmd.Context(), conf)
 		},
 	}
 
@@ -114,7 +115,7 @@ var (
 				return fmt.Errorf("environment not found")
 			}
 
-			conf, err := config.ReadPrivateKeysFrom(env.Root.MustRead(config.PrivateKeyConfigFilename))
+			conf, err := keystore.Read(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -138,7 +139,7 @@ var (
 
 				// This can take up a good bit of RAM and time. When running on the remote-test-runner, this can lead to OOM
 				// issues. So we avoid running in parallel; slower, but safer.
-				decryptedKey, err := keystore.DecryptKey(pkData, password)
+				decryptedKey, err := gethkeystore.DecryptKey(pkData, password)
 				if err != nil {
 					return err
 				}
@@ -152,7 +153,7 @@ var (
 				})
 			}
 
-			return config.WritePrivateKeys(env.Root.MustWrite(config.PrivateKeyConfigFilename), conf)
+			return keystore.Write(cmd.Context(), conf)
 		},
 	}
 )
@@ -5,8 +5,8 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/easterthebunny/automation-cli/internal/config"
 	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
 )
 
 var listCmd = &cobra.Command{
@@ -19,7 +19,7 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("environment not found")
 		}
 
-		conf, err := config.ReadPrivateKeysFrom(env.Root.MustRead(config.PrivateKeyConfigFilename))
+		conf, err := keystore.Read(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,39 @@
+package key
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Encrypt an existing plaintext key store",
+	Long: `Encrypt all keys in an existing plaintext key store with a passphrase and remove the plaintext store. Keys
+are merged into an existing encrypted key store where keys with the same alias are replaced.`,
+	Example: `The passphrase is read from the AUTOMATION_CLI_KEYSTORE_PASSWORD environment variable, a password file, or
+an interactive prompt in that order:
+
+$ automation-cli key migrate
+$ automation-cli key migrate --keystore-password-file="./password.txt"`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		count, err := keystore.Migrate(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if io.DryRunFromContext(cmd.Context()) {
+			fmt.Fprintf(cmd.OutOrStdout(), "dry run: %d keys would be migrated to the encrypted key store\n", count)
+
+			return nil
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%d keys migrated to the encrypted key store\n", count)
+
+		return nil
+	},
+}
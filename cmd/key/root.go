@@ -9,6 +9,7 @@ func init() {
 	RootCmd.AddCommand(deleteCmd)
 	RootCmd.AddCommand(importGanacheCmd)
 	RootCmd.AddCommand(importGethCmd)
+	RootCmd.AddCommand(migrateCmd)
 
 	storeCmd.Flags().BoolVar(&readStdIn, "stdin", false, "read value from standard input instead of prompting")
 	importGethCmd.Flags().StringVar(&passwordPath, "password", "", "password file path to unlock private keys")
@@ -10,6 +10,7 @@ import (
 
 	"github.com/easterthebunny/automation-cli/internal/config"
 	cliio "github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
 )
 
 var (
@@ -43,7 +44,7 @@ var (
 				return fmt.Errorf("environment not found")
 			}
 
-			conf, err := config.ReadPrivateKeysFrom(env.Root.MustRead(config.PrivateKeyConfigFilename))
+			conf, err := keystore.Read(cmd.Context())
 			if err != nil {
 				return err
 			}
@@ -52,7 +53,7 @@ var (
 				if key.Alias == args[0] {
 					conf.Keys[idx].Value = string(pkBytes)
 
-					return config.WritePrivateKeys(env.Root.MustWrite(config.PrivateKeyConfigFilename), conf)
+					return keystore.Write(cmd.Context(), conf)
 				}
 			}
 
@@ -61,7 +62,7 @@ var (
 				Value: string(pkBytes),
 			})
 
-			return config.WritePrivateKeys(env.Root.MustWrite(config.PrivateKeyConfigFilename), conf)
+			return keystore.Write(cmd.Context(), conf)
 		},
 	}
 )
@@ -40,26 +40,7 @@ $ automation-cli network bootstrap set chainlink:latest --environment="non.defau
 				return err
 			}
 
-			env.Bootstrap = &config.NodeConfig{
-				HostType:            config.Docker,
-				Name:                "bootstrap",
-				Image:               args[0],
-				LogLevel:            logLevel,
-				ListenPort:          5688,
-				LoginName:           config.DefaultChainlinkNodeLogin,
-				LoginPassword:       config.DefaultChainlinkNodePassword,
-				IsBootstrap:         true,
-				BootstrapListenPort: 8000,
-
-				ChainID: env.ChainID,
-				WSURL:   env.WSURL,
-				HTTPURL: env.HTTPURL,
-
-				MercuryLegacyURL: config.DefaultMercuryLegacyURL,
-				MercuryURL:       config.DefaultMercuryURL,
-				MercuryID:        config.DefaultMercuryID,
-				MercuryKey:       config.DefaultMercuryKey,
-			}
+			env.Bootstrap = node.NewBootstrapConfig(env, args[0], logLevel)
 
 			nodeConfigPath := fmt.Sprintf("%s/%s", basePath, "bootstrap")
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/easterthebunny/automation-cli/internal/config"
 	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
 	"github.com/easterthebunny/automation-cli/internal/node"
 )
 
@@ -82,32 +83,14 @@ This assumes the four provided keys are aliases to existing saved keys.`,
 			for idx := 0; idx < int(count); idx++ {
 				nodeID := idx + existing
 
-				nodeConf := config.NodeConfig{
-					HostType:      config.Docker,
-					Name:          fmt.Sprintf("participant-%d", nodeID),
-					Image:         args[0],
-					LogLevel:      logLevel,
-					ListenPort:    uint16(6688 + nodeID),
-					LoginName:     config.DefaultChainlinkNodeLogin,
-					LoginPassword: config.DefaultChainlinkNodePassword,
-
-					PrivateKeyAlias: alias,
-					ChainID:         env.ChainID,
-					WSURL:           env.WSURL,
-					HTTPURL:         env.HTTPURL,
-
-					MercuryLegacyURL: config.DefaultMercuryLegacyURL,
-					MercuryURL:       config.DefaultMercuryURL,
-					MercuryID:        config.DefaultMercuryID,
-					MercuryKey:       config.DefaultMercuryKey,
-				}
+				nodeConf := node.NewParticipantConfig(env, nodeID, args[0], logLevel, alias)
 
 				nodeConfigPath := fmt.Sprintf("%s/%s", basePath, nodeConf.Name)
 
 				if err := node.CreateParticipantNode(
 					cmd.Context(),
 					env.Groupname,
-					env.Registry.Address,
+					*env.Registry,
 					*env.Bootstrap,
 					&nodeConf,
 					nodeConfigPath,
@@ -142,7 +125,7 @@ func prepare(cmd *cobra.Command) (io.Environment, config.Environment, *config.Ke
 		return io.Environment{}, env, nil, err
 	}
 
-	keys, err := config.ReadPrivateKeysFrom(path.Root.MustRead(config.PrivateKeyConfigFilename))
+	keys, err := keystore.Read(cmd.Context())
 	if err != nil {
 		return io.Environment{}, env, nil, err
 	}
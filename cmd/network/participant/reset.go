@@ -84,7 +84,7 @@ This assumes the four provided keys are aliases to existing saved keys.`,
 
 			if err := node.CreateParticipantNode(
 				cmd.Context(),
-				env.Groupname, env.Registry.Address,
+				env.Groupname, *env.Registry,
 				*env.Bootstrap,
 				conf,
 				nodeConfigPath,
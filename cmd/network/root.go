@@ -12,6 +12,7 @@ func init() {
 	RootCmd.AddCommand(bootstrap.RootCmd)
 	RootCmd.AddCommand(fundCmd)
 	RootCmd.AddCommand(listCmd)
+	RootCmd.AddCommand(rpcStatusCmd)
 }
 
 var RootCmd = &cobra.Command{
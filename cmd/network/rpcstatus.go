@@ -0,0 +1,59 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/rpcpool"
+)
+
+var rpcStatusCmd = &cobra.Command{
+	Use:   "rpc-status",
+	Short: "Print the health of each configured RPC endpoint",
+	Long: `Check every HTTP and WS endpoint of the environment by reading the latest block. Lag is the number of blocks an
+endpoint trails the highest block reported by any endpoint. Transactions and reads are routed to healthy HTTP endpoints
+with the lowest lag and latency first. Nodes created for the environment fail over between all HTTP and WS endpoints.`,
+	Example: `To add fallback endpoints and check all endpoints:
+
+$ automation-cli configure set http-urls https://rpc-1.example,https://rpc-2.example
+$ automation-cli configure set ws-urls wss://rpc-1.example,wss://rpc-2.example
+$ automation-cli network rpc-status`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		path := io.EnvironmentFromContext(cmd.Context())
+		if path == nil {
+			return fmt.Errorf("environment not found")
+		}
+
+		env, err := config.ReadFrom(path.MustRead(config.EnvironmentConfigFilename))
+		if err != nil {
+			return err
+		}
+
+		endpoints := append(env.HTTPEndpoints(), env.WSEndpoints()...)
+		if len(endpoints) == 0 {
+			return rpcpool.ErrNoEndpoints
+		}
+
+		writer := table.NewWriter()
+
+		writer.AppendHeader(table.Row{"Endpoint", "Type", "Block", "Lag", "Latency", "Status"})
+
+		for _, health := range rpcpool.CheckEndpoints(cmd.Context(), endpoints) {
+			writer.AppendRow(table.Row{
+				health.URL, health.Transport(), health.Block, health.Lag, health.Latency.Round(time.Millisecond), health.Status(),
+			})
+		}
+
+		writer.SetStyle(table.StyleLight)
+
+		fmt.Fprintln(cmd.OutOrStdout(), writer.Render())
+
+		return nil
+	},
+}
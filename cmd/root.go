@@ -1,14 +1,24 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/easterthebunny/automation-cli/cmd/call"
 	"github.com/easterthebunny/automation-cli/cmd/configure"
 	"github.com/easterthebunny/automation-cli/cmd/contract"
+	"github.com/easterthebunny/automation-cli/cmd/down"
 	"github.com/easterthebunny/automation-cli/cmd/key"
 	"github.com/easterthebunny/automation-cli/cmd/network"
+	"github.com/easterthebunny/automation-cli/cmd/tx"
+	"github.com/easterthebunny/automation-cli/cmd/up"
+	"github.com/easterthebunny/automation-cli/internal/config"
 	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
 )
 
 func init() {
@@ -16,6 +26,9 @@ func init() {
 	rootCmd.AddCommand(key.RootCmd)
 	rootCmd.AddCommand(contract.RootCmd)
 	rootCmd.AddCommand(network.RootCmd)
+	rootCmd.AddCommand(tx.RootCmd)
+	rootCmd.AddCommand(up.RootCmd)
+	rootCmd.AddCommand(down.RootCmd)
 
 	rootCmd.AddCommand(call.RootCmd)
 
@@ -36,6 +49,24 @@ func init() {
 		"",
 		"use to override configured state private key for command",
 	)
+
+	_ = rootCmd.PersistentFlags().Bool(
+		"dry-run",
+		false,
+		"simulate transactions and print environment changes without sending or writing anything",
+	)
+
+	_ = rootCmd.PersistentFlags().String(
+		"confirmations",
+		"",
+		"blocks to wait after a transaction is mined or 'safe' or 'finalized'; overrides the environment setting",
+	)
+
+	_ = rootCmd.PersistentFlags().String(
+		"keystore-password-file",
+		"",
+		"file containing the passphrase to unlock the encrypted key store",
+	)
 }
 
 var rootCmd = &cobra.Command{
@@ -49,7 +80,34 @@ var rootCmd = &cobra.Command{
 			return err
 		}
 
+		passwordFile, err := cmd.Flags().GetString("keystore-password-file")
+		if err != nil {
+			return err
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+
+		env.DryRun = dryRun
+
+		confirmations, err := cmd.Flags().GetString("confirmations")
+		if err != nil {
+			return err
+		}
+
+		if confirmations != "" {
+			if _, err := config.ParseConfirmations(confirmations); err != nil {
+				return err
+			}
+		}
+
 		ctx := io.ContextWithEnvironment(cmd.Context(), env)
+		ctx = io.ContextWithDryRun(ctx, dryRun)
+		ctx = io.ContextWithConfirmations(ctx, confirmations)
+		ctx = io.ContextWithPassphrase(ctx, keystore.NewPassphraseFunc(passwordFile, os.Stdin, cmd.ErrOrStderr()))
+		ctx = io.ContextWithCommand(ctx, commandLine(cmd, args))
 
 		cmd.SetContext(ctx)
 
@@ -77,3 +135,14 @@ func getEnvironmentStatePath(cmd *cobra.Command) (io.Environment, error) {
 		Name: selectedEnv,
 	}, nil
 }
+
+// commandLine rebuilds the command as it was run from the command path, arguments, and flags that were set.
+func commandLine(cmd *cobra.Command, args []string) string {
+	parts := append([]string{cmd.CommandPath()}, args...)
+
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		parts = append(parts, fmt.Sprintf("--%s=%s", flag.Name, flag.Value.String()))
+	})
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,98 @@
+package tx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/journal"
+)
+
+func init() {
+	historyCmd.Flags().StringVar(&historyFilter.Method, "method", "", "only show transactions calling the method")
+	historyCmd.Flags().StringVar(&historyFilter.From, "from", "", "only show transactions sent from the address")
+	historyCmd.Flags().StringVar(&historyFilter.To, "to", "", "only show transactions sent to the address")
+	historyCmd.Flags().StringVar(
+		&historyFilter.Status, "status", "", "only show transactions with the status (pending, success, failed, replaced)")
+	historyCmd.Flags().StringVar(
+		&historyFilter.Command, "command", "", "only show transactions from commands containing the value")
+	historyCmd.Flags().IntVar(&historyFilter.Limit, "limit", 0, "only show the most recent transactions")
+	historyCmd.Flags().StringVar(&historyFormat, "format", "text", "output format (text, json)")
+}
+
+var (
+	historyFilter journal.Filter
+	historyFormat string
+
+	historyCmd = &cobra.Command{
+		Use:   "history",
+		Short: "List transactions recorded in the environment journal",
+		Long: `List transactions sent from the cli in the selected environment. Every transaction a node accepts is
+recorded in the environment journal with the decoded method, gas used, status, and the command that sent it. A
+transaction is listed as pending from when it is sent until its outcome is known and remains pending when the command
+stops before waiting on it.`,
+		Example: `To list the last 10 failed transactions:
+
+$ automation-cli tx history --status=failed --limit=10
+
+To list all upkeep registrations sent by the up command as JSON:
+
+$ automation-cli tx history --method=registerUpkeep --command="automation-cli up" --format=json`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path := io.EnvironmentFromContext(cmd.Context())
+			if path == nil {
+				return fmt.Errorf("environment not found")
+			}
+
+			entries, err := journal.Read(*path)
+			if err != nil {
+				return err
+			}
+
+			entries = historyFilter.Apply(journal.Latest(entries))
+
+			switch historyFormat {
+			case "json":
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+
+				return encoder.Encode(entries)
+			case "text":
+				writer := table.NewWriter()
+
+				writer.AppendHeader(table.Row{"Block", "Hash", "Nonce", "From", "To", "Method", "Gas Used", "Status", "Command"})
+
+				for _, entry := range entries {
+					writer.AppendRow(table.Row{
+						entry.Block, entry.Hash, entry.Nonce, shortAddress(entry.From), shortAddress(entry.To),
+						entry.Method, entry.GasUsed, entry.Status, entry.Command,
+					})
+				}
+
+				writer.SetStyle(table.StyleLight)
+
+				fmt.Fprintln(cmd.OutOrStdout(), writer.Render())
+
+				return nil
+			default:
+				return fmt.Errorf("unknown format '%s'", historyFormat)
+			}
+		},
+	}
+)
+
+// shortAddress abbreviates an address to the first and last four hex characters.
+func shortAddress(address string) string {
+	const keep = 6
+
+	if len(address) <= 2*keep {
+		return address
+	}
+
+	return strings.Join([]string{address[:keep], address[len(address)-keep+2:]}, "..")
+}
@@ -0,0 +1,111 @@
+package tx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+)
+
+var (
+	speedupCmd = &cobra.Command{
+		Use:   "speedup [NONCE]",
+		Short: "Resubmit a pending transaction with higher fees",
+		Long: `Resubmit the pending transaction at the provided nonce with fees bumped by the configured percentage. The
+pending transaction is read from the node transaction pool or selected by the --hash flag.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nonce, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid nonce: %w", err)
+			}
+
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, err := asset.NewDeployer(&env, key)
+			if err != nil {
+				return err
+			}
+
+			trx, err := pendingTransaction(cmd.Context(), deployer, nonce)
+			if err != nil {
+				return err
+			}
+
+			if trx == nil {
+				return fmt.Errorf("no pending transaction found for nonce %d; provide --hash", nonce)
+			}
+
+			replacement, err := deployer.SpeedUp(cmd.Context(), trx)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "transaction %s replaced by %s\n", trx.Hash(), replacement.Hash())
+
+			return nil
+		},
+	}
+
+	cancelCmd = &cobra.Command{
+		Use:   "cancel [NONCE]",
+		Short: "Replace a pending transaction with an empty transfer",
+		Long: `Replace the pending transaction at the provided nonce with an empty transfer to the sending key. Fees are
+bumped from the pending transaction when it is found and from currently suggested fees otherwise.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nonce, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid nonce: %w", err)
+			}
+
+			_, env, key, err := prepare(cmd)
+			if err != nil {
+				return err
+			}
+
+			deployer, err := asset.NewDeployer(&env, key)
+			if err != nil {
+				return err
+			}
+
+			trx, err := pendingTransaction(cmd.Context(), deployer, nonce)
+			if err != nil {
+				return err
+			}
+
+			replacement, err := deployer.Cancel(cmd.Context(), nonce, trx)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "nonce %d cancelled by %s\n", nonce, replacement.Hash())
+
+			return nil
+		},
+	}
+)
+
+func pendingTransaction(ctx context.Context, deployer *asset.Deployer, nonce uint64) (*types.Transaction, error) {
+	if txHash == "" {
+		return deployer.PendingTransaction(ctx, nonce)
+	}
+
+	trx, err := deployer.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if trx.Nonce() != nonce {
+		return nil, fmt.Errorf("transaction %s has nonce %d", txHash, trx.Nonce())
+	}
+
+	return trx, nil
+}
@@ -0,0 +1,84 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
+)
+
+func init() {
+	RootCmd.AddCommand(speedupCmd)
+	RootCmd.AddCommand(cancelCmd)
+	RootCmd.AddCommand(historyCmd)
+	RootCmd.AddCommand(showCmd)
+
+	RootCmd.PersistentFlags().StringVar(
+		&txHash, "hash", "",
+		"hash of the pending transaction when the node does not expose its transaction pool")
+}
+
+var (
+	txHash string
+
+	RootCmd = &cobra.Command{
+		Use:   "tx [ACTION]",
+		Short: "Manage transactions sent from stored keys",
+		Long:  `Inspect, replace, and review the history of transactions sent from stored keys.`,
+		Example: `Pending transactions are selected by nonce for the key provided by the --key flag or the environment private
+key. To replace a stuck transaction at nonce 42 with higher fees:
+
+$ automation-cli tx speedup 42 --key="mumbai-dev"
+
+To replace it with an empty transfer to the same key instead:
+
+$ automation-cli tx cancel 42 --key="mumbai-dev"
+
+To list the transactions sent in the environment:
+
+$ automation-cli tx history`,
+		Args: cobra.MinimumNArgs(1),
+	}
+)
+
+func prepare(cmd *cobra.Command) (io.Environment, config.Environment, config.Key, error) {
+	var (
+		env config.Environment
+		key config.Key
+		err error
+	)
+
+	path := io.EnvironmentFromContext(cmd.Context())
+	if path == nil {
+		return io.Environment{}, env, key, fmt.Errorf("environment not found")
+	}
+
+	env, err = config.ReadFrom(path.MustRead(config.EnvironmentConfigFilename))
+	if err != nil {
+		return io.Environment{}, env, key, err
+	}
+
+	keys, err := keystore.Read(cmd.Context())
+	if err != nil {
+		return io.Environment{}, env, key, err
+	}
+
+	pkOverride, err := cmd.Flags().GetString("key")
+	if err != nil {
+		return io.Environment{}, env, key, err
+	}
+
+	if pkOverride == "" {
+		pkOverride = env.PrivateKeyAlias
+	}
+
+	key, err = keys.KeyForAlias(pkOverride)
+	if err != nil {
+		return io.Environment{}, env, key, err
+	}
+
+	return *path, env, key, nil
+}
@@ -0,0 +1,149 @@
+package tx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/decode"
+	"github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/journal"
+	"github.com/easterthebunny/automation-cli/internal/rpcpool"
+)
+
+var (
+	showCmd = &cobra.Command{
+		Use:   "show [HASH]",
+		Short: "Show a transaction with decoded calldata and receipt logs",
+		Long: `Show a transaction and its receipt from the environment RPC. Calldata and receipt logs are decoded with the
+ABIs of the contracts the cli deploys and the command that sent the transaction is read from the environment journal.`,
+		Example: `$ automation-cli tx show 0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := io.EnvironmentFromContext(cmd.Context())
+			if path == nil {
+				return fmt.Errorf("environment not found")
+			}
+
+			env, err := config.ReadFrom(path.MustRead(config.EnvironmentConfigFilename))
+			if err != nil {
+				return err
+			}
+
+			rpcClient, err := rpcpool.Dial(cmd.Context(), env.HTTPEndpoints())
+			if err != nil {
+				return err
+			}
+
+			client := ethclient.NewClient(rpcClient)
+
+			hash := common.HexToHash(args[0])
+
+			trx, pending, err := client.TransactionByHash(cmd.Context(), hash)
+			if err != nil {
+				return fmt.Errorf("transaction %s: %w", hash, err)
+			}
+
+			out := cmd.OutOrStdout()
+
+			fmt.Fprintf(out, "hash:       %s\n", trx.Hash())
+			fmt.Fprintf(out, "nonce:      %d\n", trx.Nonce())
+
+			if from, err := types.Sender(types.LatestSignerForChainID(trx.ChainId()), trx); err == nil {
+				fmt.Fprintf(out, "from:       %s\n", from)
+			}
+
+			if trx.To() != nil {
+				fmt.Fprintf(out, "to:         %s\n", trx.To())
+			}
+
+			fmt.Fprintf(out, "value:      %s\n", trx.Value())
+
+			if entries, err := journal.Read(*path); err == nil {
+				if entry, ok := journal.Find(entries, hash.Hex()); ok {
+					fmt.Fprintf(out, "command:    %s\n", entry.Command)
+				}
+			}
+
+			writeCall(cmd, trx)
+
+			if pending {
+				fmt.Fprintln(out, "status:     pending")
+
+				return nil
+			}
+
+			receipt, err := client.TransactionReceipt(cmd.Context(), hash)
+			if err != nil {
+				if errors.Is(err, ethereum.NotFound) {
+					fmt.Fprintln(out, "status:     pending")
+
+					return nil
+				}
+
+				return err
+			}
+
+			status := journal.StatusSuccess
+			if receipt.Status == types.ReceiptStatusFailed {
+				status = journal.StatusFailed
+			}
+
+			fmt.Fprintf(out, "status:     %s\n", status)
+			fmt.Fprintf(out, "block:      %s\n", receipt.BlockNumber)
+			fmt.Fprintf(out, "gas used:   %d\n", receipt.GasUsed)
+
+			if receipt.ContractAddress != (common.Address{}) {
+				fmt.Fprintf(out, "contract:   %s\n", receipt.ContractAddress)
+			}
+
+			fmt.Fprintf(out, "logs:       %d\n", len(receipt.Logs))
+
+			for _, log := range receipt.Logs {
+				event, err := decode.Log(*log)
+				if err != nil {
+					fmt.Fprintf(out, "  [%d] %s: %s\n", log.Index, log.Address, err)
+
+					continue
+				}
+
+				fmt.Fprintf(out, "  [%d] %s %s.%s\n", log.Index, log.Address, event.Contract, event.Name)
+				writeArgs(cmd, event.Args)
+			}
+
+			return nil
+		},
+	}
+)
+
+func writeCall(cmd *cobra.Command, trx *types.Transaction) {
+	out := cmd.OutOrStdout()
+
+	if trx.To() == nil || len(trx.Data()) == 0 {
+		fmt.Fprintf(out, "method:     %s\n", decode.MethodName(trx))
+
+		return
+	}
+
+	call, err := decode.Method(trx.Data())
+	if err != nil {
+		fmt.Fprintf(out, "method:     %s (%s)\n", decode.MethodUnknown, err)
+
+		return
+	}
+
+	fmt.Fprintf(out, "method:     %s.%s\n", call.Contract, call.Method)
+	writeArgs(cmd, call.Args)
+}
+
+func writeArgs(cmd *cobra.Command, args map[string]any) {
+	for _, arg := range decode.FormatArgs(args) {
+		fmt.Fprintf(cmd.OutOrStdout(), "      %s\n", arg)
+	}
+}
@@ -0,0 +1,149 @@
+package up
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/config"
+	cliio "github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
+)
+
+func init() {
+	RootCmd.Flags().StringVarP(&specPath, "file", "f", "", "path to the environment spec file (toml)")
+
+	_ = RootCmd.MarkFlagRequired("file")
+}
+
+var (
+	specPath string
+
+	RootCmd = &cobra.Command{
+		Use:   "up",
+		Short: "Build a complete automation environment from a spec file",
+		Long: `Build a complete automation environment from a single spec file. Contracts are deployed, nodes are created,
+and the registry is configured in dependency order. Progress is saved to the environment after every step such that a
+failed run can be repeated and will continue where it stopped without redeploying existing assets.`,
+		Example: `A minimal spec deploys mocked LINK contracts, a registry and registrar, a bootstrap node, 4 participant
+nodes, and both verifiable load contracts:
+
+chain-id = 1337
+http-url = "http://127.0.0.1:7545"
+ws-url = "ws://127.0.0.1:7545"
+private-key-alias = "geth-local-0"
+
+[fast-gas-feed]
+answer = "6e10"
+
+[nodes]
+image = "chainlink:local"
+count = 4
+keys = ["geth-local-1", "geth-local-2", "geth-local-3", "geth-local-4"]
+
+[[load]]
+type = "conditional"
+
+[[load]]
+type = "log-trigger"
+
+$ automation-cli up -f env.toml --environment="geth.local"`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			file, err := os.Open(specPath)
+			if err != nil {
+				return err
+			}
+
+			spec, err := config.ReadSpecFrom(file)
+			if err != nil {
+				return fmt.Errorf("failed to read spec: %w", err)
+			}
+
+			path := cliio.EnvironmentFromContext(cmd.Context())
+			if path == nil {
+				return fmt.Errorf("environment not found")
+			}
+
+			env, err := config.ReadFrom(path.MustRead(config.EnvironmentConfigFilename))
+			if err != nil {
+				return err
+			}
+
+			applySpec(&env, spec, path.Name)
+
+			keys, err := keystore.Read(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			pkOverride, err := cmd.Flags().GetString("key")
+			if err != nil {
+				return err
+			}
+
+			if pkOverride == "" {
+				pkOverride = env.PrivateKeyAlias
+			}
+
+			key, err := keys.KeyForAlias(pkOverride)
+			if err != nil {
+				return err
+			}
+
+			deployer, err := asset.NewDeployer(&env, key)
+			if err != nil {
+				return err
+			}
+
+			run := &runner{
+				path:     *path,
+				env:      &env,
+				spec:     spec,
+				keys:     keys,
+				deployer: deployer,
+				writer:   cmd.OutOrStdout(),
+			}
+
+			return run.execute(cmd.Context(), buildSteps(spec))
+		},
+	}
+)
+
+// applySpec copies the chain level spec values to the environment. Values not provided by the spec leave the existing
+// environment unchanged.
+func applySpec(env *config.Environment, spec config.Spec, name string) {
+	if spec.Groupname != "" {
+		env.Groupname = spec.Groupname
+	}
+
+	if env.Groupname == "" {
+		env.Groupname = name
+	}
+
+	if spec.ChainID != 0 {
+		env.ChainID = spec.ChainID
+	}
+
+	if spec.HTTPURL != "" {
+		env.HTTPURL = spec.HTTPURL
+	}
+
+	if spec.WSURL != "" {
+		env.WSURL = spec.WSURL
+	}
+
+	if spec.PrivateKeyAlias != "" {
+		env.PrivateKeyAlias = spec.PrivateKeyAlias
+	}
+
+	if spec.GasLimit != 0 {
+		env.GasLimit = spec.GasLimit
+	}
+
+	if env.GasLimit == 0 {
+		env.GasLimit = config.DefaultDeployerGasLimit
+	}
+}
@@ -0,0 +1,502 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/easterthebunny/automation-cli/internal/asset"
+	"github.com/easterthebunny/automation-cli/internal/config"
+	cliio "github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/node"
+	"github.com/easterthebunny/automation-cli/internal/util"
+)
+
+const (
+	defaultLinkETHAnswer = "2e18"
+	defaultFastGasAnswer = "6e10"
+	defaultNodeLogLevel  = "error"
+
+	registryConfigStep = "registry-config"
+)
+
+type step struct {
+	name string
+	// done reports whether the step has already been completed by a previous run or command
+	done func(*runner) bool
+	run  func(context.Context, *runner) error
+}
+
+type runner struct {
+	path     cliio.Environment
+	env      *config.Environment
+	spec     config.Spec
+	keys     config.PrivateKeys
+	deployer *asset.Deployer
+	writer   io.Writer
+}
+
+// execute runs each step that is not already complete and saves the environment after each one. In dry run mode,
+// steps are listed without running them because later steps depend on nodes and contracts created by earlier ones.
+func (r *runner) execute(ctx context.Context, steps []step) error {
+	for _, stp := range steps {
+		if stp.done(r) {
+			fmt.Fprintf(r.writer, "[%s] already complete\n", stp.name)
+
+			continue
+		}
+
+		if r.path.DryRun {
+			fmt.Fprintf(r.writer, "[%s] would run\n", stp.name)
+
+			continue
+		}
+
+		fmt.Fprintf(r.writer, "[%s] running\n", stp.name)
+
+		if err := stp.run(ctx, r); err != nil {
+			return fmt.Errorf("step '%s' failed: %w", stp.name, err)
+		}
+
+		r.complete(stp.name)
+
+		if err := config.Write(r.path.MustWrite(config.EnvironmentConfigFilename), *r.env); err != nil {
+			return err
+		}
+	}
+
+	if r.path.DryRun {
+		// show the values applied from the spec
+		return config.Write(r.path.MustWrite(config.EnvironmentConfigFilename), *r.env)
+	}
+
+	return nil
+}
+
+func (r *runner) completed(name string) bool {
+	for _, completed := range r.env.CompletedSteps {
+		if completed == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *runner) complete(name string) {
+	if !r.completed(name) {
+		r.env.CompletedSteps = append(r.env.CompletedSteps, name)
+	}
+}
+
+func (r *runner) invalidate(name string) {
+	for idx, completed := range r.env.CompletedSteps {
+		if completed == name {
+			r.env.CompletedSteps = append(r.env.CompletedSteps[:idx], r.env.CompletedSteps[idx+1:]...)
+
+			return
+		}
+	}
+}
+
+func buildSteps(spec config.Spec) []step {
+	steps := []step{
+		{name: "link-token", done: linkTokenDone, run: runLinkToken},
+		{name: "link-eth-feed", done: linkETHFeedDone, run: runLinkETHFeed},
+		{name: "fast-gas-feed", done: fastGasFeedDone, run: runFastGasFeed},
+		{name: "registry", done: registryDone, run: runRegistry},
+		{name: "registrar", done: registrarDone, run: runRegistrar},
+		{name: "bootstrap", done: bootstrapDone, run: runBootstrap},
+	}
+
+	for idx := 0; idx < int(spec.Nodes.Count); idx++ {
+		nodeIdx := idx
+
+		steps = append(steps, step{
+			name: fmt.Sprintf("participant-%d", nodeIdx),
+			done: func(r *runner) bool { return len(r.env.Participants) > nodeIdx },
+			run: func(ctx context.Context, r *runner) error {
+				return runParticipant(ctx, r, nodeIdx)
+			},
+		})
+
+		if spec.Nodes.Fund != "" {
+			name := fmt.Sprintf("fund-participant-%d", nodeIdx)
+
+			steps = append(steps, step{
+				name: name,
+				done: func(r *runner) bool { return r.completed(name) },
+				run: func(ctx context.Context, r *runner) error {
+					return runFundParticipant(ctx, r, nodeIdx)
+				},
+			})
+		}
+	}
+
+	steps = append(steps, step{
+		name: registryConfigStep,
+		done: func(r *runner) bool { return r.completed(registryConfigStep) },
+		run:  runRegistryConfig,
+	})
+
+	for _, load := range spec.Load {
+		loadConf := load
+
+		steps = append(steps, step{
+			name: fmt.Sprintf("verifiable-load-%s", loadConf.Type),
+			done: func(r *runner) bool {
+				contract := loadContractFor(r.env, loadConf.Type)
+
+				return contract != nil && contract.Address != ""
+			},
+			run: func(ctx context.Context, r *runner) error {
+				return runVerifiableLoad(ctx, r, loadConf)
+			},
+		})
+	}
+
+	return steps
+}
+
+func linkTokenDone(r *runner) bool {
+	return r.env.LinkToken != nil && r.env.LinkToken.Address != ""
+}
+
+func runLinkToken(ctx context.Context, r *runner) error {
+	if r.spec.LinkToken.Address != "" {
+		if !common.IsHexAddress(r.spec.LinkToken.Address) {
+			return fmt.Errorf("link token address must be hex encoded")
+		}
+
+		r.env.LinkToken = &config.LinkTokenContract{Address: r.spec.LinkToken.Address}
+
+		return nil
+	}
+
+	r.env.LinkToken = &config.LinkTokenContract{Mocked: true}
+
+	_, err := asset.NewLinkTokenDeployable(r.env.LinkToken).Deploy(ctx, r.deployer)
+
+	return err
+}
+
+func linkETHFeedDone(r *runner) bool {
+	return r.env.LinkETH != nil && r.env.LinkETH.Address != ""
+}
+
+func runLinkETHFeed(ctx context.Context, r *runner) error {
+	feed, err := feedFromSpec(r.spec.LinkETHFeed, defaultLinkETHAnswer)
+	if err != nil {
+		return err
+	}
+
+	r.env.LinkETH = feed
+
+	if !feed.Mocked {
+		return nil
+	}
+
+	_, err = asset.NewLinkETHFeedDeployable(r.env.LinkETH).Deploy(ctx, r.deployer)
+
+	return err
+}
+
+func fastGasFeedDone(r *runner) bool {
+	return r.env.FastGas != nil && r.env.FastGas.Address != ""
+}
+
+func runFastGasFeed(ctx context.Context, r *runner) error {
+	feed, err := feedFromSpec(r.spec.FastGasFeed, defaultFastGasAnswer)
+	if err != nil {
+		return err
+	}
+
+	r.env.FastGas = feed
+
+	if !feed.Mocked {
+		return nil
+	}
+
+	_, err = asset.NewFastGasFeedDeployable(r.env.FastGas).Deploy(ctx, r.deployer)
+
+	return err
+}
+
+func feedFromSpec(spec config.SpecFeed, defaultAnswer string) (*config.FeedContract, error) {
+	if spec.Address != "" {
+		if !common.IsHexAddress(spec.Address) {
+			return nil, fmt.Errorf("feed address must be hex encoded")
+		}
+
+		return &config.FeedContract{Address: spec.Address}, nil
+	}
+
+	answer := spec.Answer
+	if answer == "" {
+		answer = defaultAnswer
+	}
+
+	amount, err := util.ParseExp(answer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.FeedContract{
+		Mocked:        true,
+		DefaultAnswer: amount.Uint64(),
+	}, nil
+}
+
+func registryDone(r *runner) bool {
+	return r.env.Registry != nil && r.env.Registry.Address != ""
+}
+
+func runRegistry(ctx context.Context, r *runner) error {
+	version := r.spec.Registry.Version
+	if version == "" {
+		version = config.RegistryVersion21
+	}
+
+	registry, err := asset.NewDefaultRegistryConfig(version, config.GetRegistryMode(r.spec.Registry.Mode))
+	if err != nil {
+		return err
+	}
+
+	r.env.Registry = registry
+
+	if r.spec.Registry.Address != "" {
+		if !common.IsHexAddress(r.spec.Registry.Address) {
+			return fmt.Errorf("registry address must be hex encoded")
+		}
+
+		r.env.Registry.Address = r.spec.Registry.Address
+
+		return nil
+	}
+
+	deployable, err := asset.NewRegistryDeployable(*r.env.LinkToken, *r.env.LinkETH, *r.env.FastGas, r.env.Registry)
+	if err != nil {
+		return err
+	}
+
+	_, err = deployable.Deploy(ctx, r.deployer)
+
+	return err
+}
+
+func registrarDone(r *runner) bool {
+	return r.env.Registrar != nil && r.env.Registrar.Address != ""
+}
+
+func runRegistrar(ctx context.Context, r *runner) error {
+	registrar, err := asset.NewDefaultRegistrarConfig(r.env.Registry.ContractVersion())
+	if err != nil {
+		return err
+	}
+
+	r.env.Registrar = registrar
+
+	if r.spec.Registrar.Address != "" {
+		if !common.IsHexAddress(r.spec.Registrar.Address) {
+			return fmt.Errorf("registrar address must be hex encoded")
+		}
+
+		r.env.Registrar.Address = r.spec.Registrar.Address
+	} else {
+		deployable, err := asset.NewRegistrarDeployable(*r.env.LinkToken, *r.env.Registry, r.env.Registrar)
+		if err != nil {
+			return err
+		}
+
+		if _, err := deployable.Deploy(ctx, r.deployer); err != nil {
+			return err
+		}
+	}
+
+	r.env.Registry.Onchain.Registrars = []string{r.env.Registrar.Address}
+
+	// the registrar is only permitted on-chain after the next registry config is set
+	r.invalidate(registryConfigStep)
+
+	return nil
+}
+
+func bootstrapDone(r *runner) bool {
+	return r.env.Bootstrap != nil && r.env.Bootstrap.BootstrapAddress != ""
+}
+
+func runBootstrap(ctx context.Context, r *runner) error {
+	basePath, err := r.path.Path()
+	if err != nil {
+		return err
+	}
+
+	image := r.spec.Nodes.BootstrapImage
+	if image == "" {
+		image = r.spec.Nodes.Image
+	}
+
+	if image == "" {
+		return fmt.Errorf("node image required to create bootstrap node")
+	}
+
+	logLevel := r.spec.Nodes.BootstrapLogLevel
+	if logLevel == "" {
+		logLevel = defaultNodeLogLevel
+	}
+
+	r.env.Bootstrap = node.NewBootstrapConfig(*r.env, image, logLevel)
+
+	return node.CreateBootstrapNode(
+		ctx,
+		r.env.Groupname,
+		r.env.Registry.Address,
+		r.env.Bootstrap, fmt.Sprintf("%s/%s", basePath, "bootstrap"), true)
+}
+
+func runParticipant(ctx context.Context, r *runner, nodeIdx int) error {
+	basePath, err := r.path.Path()
+	if err != nil {
+		return err
+	}
+
+	if r.spec.Nodes.Image == "" {
+		return fmt.Errorf("node image required to create participant nodes")
+	}
+
+	logLevel := r.spec.Nodes.LogLevel
+	if logLevel == "" {
+		logLevel = defaultNodeLogLevel
+	}
+
+	var privateKey *string
+
+	alias := "default"
+
+	if nodeIdx < len(r.spec.Nodes.Keys) {
+		key, err := r.keys.KeyForAlias(r.spec.Nodes.Keys[nodeIdx])
+		if err != nil {
+			return err
+		}
+
+		privateKey = &key.Value
+		alias = key.Alias
+	}
+
+	nodeConf := node.NewParticipantConfig(*r.env, len(r.env.Participants), r.spec.Nodes.Image, logLevel, alias)
+
+	if err := node.CreateParticipantNode(
+		ctx,
+		r.env.Groupname,
+		*r.env.Registry,
+		*r.env.Bootstrap,
+		&nodeConf,
+		fmt.Sprintf("%s/%s", basePath, nodeConf.Name),
+		privateKey,
+		false,
+	); err != nil {
+		return err
+	}
+
+	r.env.Participants = append(r.env.Participants, nodeConf)
+
+	// the participant set changed so the registry must be configured again
+	r.invalidate(registryConfigStep)
+
+	return nil
+}
+
+func runFundParticipant(ctx context.Context, r *runner, nodeIdx int) error {
+	amount, err := util.ParseExp(r.spec.Nodes.Fund)
+	if err != nil {
+		return err
+	}
+
+	return r.deployer.Send(ctx, r.env.Participants[nodeIdx].Address, amount)
+}
+
+func runRegistryConfig(ctx context.Context, r *runner) error {
+	if len(r.env.Participants) == 0 {
+		return fmt.Errorf("participant nodes required to set registry config")
+	}
+
+	maxFaulty := r.spec.Registry.MaxFaulty
+	if maxFaulty == 0 {
+		maxFaulty = 1
+	}
+
+	r.env.Registry.OCRNetwork.MaxFaultyNodes = int(maxFaulty)
+
+	interactable, err := asset.NewRegistryDeployable(*r.env.LinkToken, *r.env.LinkETH, *r.env.FastGas, r.env.Registry)
+	if err != nil {
+		return err
+	}
+
+	if _, err := interactable.Connect(ctx, r.deployer); err != nil {
+		return err
+	}
+
+	return interactable.SetOffchainConfig(ctx, r.deployer, r.env.Participants)
+}
+
+func loadContractFor(env *config.Environment, loadType config.VerifiableLoadType) *config.VerifiableLoadContract {
+	switch loadType {
+	case config.ConditionalLoad:
+		return env.ConditionalLoad
+	case config.LogTriggerLoad:
+		return env.LogLoad
+	default:
+		return nil
+	}
+}
+
+func runVerifiableLoad(ctx context.Context, r *runner, spec config.SpecLoad) error {
+	contract := &config.VerifiableLoadContract{
+		Type:        config.AutomationVerifiableLoadContractType,
+		LoadType:    spec.Type,
+		Address:     spec.Address,
+		UseMercury:  spec.UseMercury,
+		UseArbitrum: spec.UseArbitrum,
+	}
+
+	if spec.Address != "" && !common.IsHexAddress(spec.Address) {
+		return fmt.Errorf("verifiable load address must be hex encoded")
+	}
+
+	switch spec.Type {
+	case config.ConditionalLoad:
+		r.env.ConditionalLoad = contract
+
+		if spec.Address != "" {
+			return nil
+		}
+
+		deployable, err := asset.NewVerifiableLoadConditionalDeployable(*r.env.Registrar, contract)
+		if err != nil {
+			return err
+		}
+
+		_, err = deployable.Deploy(ctx, r.deployer)
+
+		return err
+	case config.LogTriggerLoad:
+		r.env.LogLoad = contract
+
+		if spec.Address != "" {
+			return nil
+		}
+
+		deployable, err := asset.NewVerifiableLoadLogTriggerDeployable(*r.env.Registrar, contract)
+		if err != nil {
+			return err
+		}
+
+		_, err = deployable.Deploy(ctx, r.deployer)
+
+		return err
+	default:
+		return fmt.Errorf("unknown verifiable load type '%s'", spec.Type)
+	}
+}
@@ -0,0 +1,27 @@
+package asset
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Client is the eth client of a deployer. Every transaction sent through the client is reported to the deployer
+// with the result of the send such that the nonces of transactions a node rejected can be handed out again.
+type Client struct {
+	*ethclient.Client
+
+	sent func(context.Context, *types.Transaction, error)
+}
+
+// SendTransaction sends the signed transaction and reports the result to the deployer.
+func (c *Client) SendTransaction(ctx context.Context, trx *types.Transaction) error {
+	err := c.Client.SendTransaction(ctx, trx)
+
+	if c.sent != nil {
+		c.sent(ctx, trx, err)
+	}
+
+	return err
+}
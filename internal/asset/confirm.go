@@ -0,0 +1,109 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+	cliio "github.com/easterthebunny/automation-cli/internal/io"
+)
+
+const (
+	defaultConfirmations uint16 = 10
+)
+
+// confirmationsFor returns the confirmation policy of the command in the context, or of the environment when the
+// command does not override it.
+func (d *Deployer) confirmationsFor(ctx context.Context) (config.ConfirmationConfig, error) {
+	if override := cliio.ConfirmationsFromContext(ctx); override != "" {
+		return config.ParseConfirmations(override)
+	}
+
+	if d.Config.Confirmations != nil {
+		return *d.Config.Confirmations, nil
+	}
+
+	return config.ConfirmationConfig{Mode: config.DepthConfirmation, Depth: defaultConfirmations}, nil
+}
+
+// waitConfirmations waits until the block containing the receipt is confirmed by the configured policy. The number of
+// blocks remaining is printed each time it changes.
+func (d *Deployer) waitConfirmations(ctx context.Context, receipt *types.Receipt) error {
+	policy, err := d.confirmationsFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		target = receipt.BlockNumber.Uint64()
+		tag    *big.Int
+		label  string
+	)
+
+	switch policy.Mode {
+	case config.SafeConfirmation:
+		tag = big.NewInt(int64(rpc.SafeBlockNumber))
+		label = "safe"
+	case config.FinalizedConfirmation:
+		tag = big.NewInt(int64(rpc.FinalizedBlockNumber))
+		label = "finalized"
+	case config.DepthConfirmation, "":
+		if policy.Depth == 0 {
+			return nil
+		}
+
+		target += uint64(policy.Depth)
+		label = fmt.Sprintf("%d confirmations", policy.Depth)
+	default:
+		return fmt.Errorf("unknown confirmation mode '%s'", policy.Mode)
+	}
+
+	queryTicker := time.NewTicker(time.Second)
+	defer queryTicker.Stop()
+
+	var lastRemaining uint64
+
+	for {
+		block, err := d.confirmedBlock(ctx, tag)
+		if err != nil {
+			return err
+		}
+
+		if block >= target {
+			return nil
+		}
+
+		if remaining := target - block; remaining != lastRemaining {
+			fmt.Printf("waiting for %s of block %d: %d blocks remaining\n", label, receipt.BlockNumber, remaining)
+
+			lastRemaining = remaining
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-queryTicker.C:
+		}
+	}
+}
+
+// confirmedBlock returns the number of the block with the provided tag or the latest block when no tag is provided.
+func (d *Deployer) confirmedBlock(ctx context.Context, tag *big.Int) (uint64, error) {
+	if tag == nil {
+		return d.Client.BlockNumber(ctx)
+	}
+
+	header, err := d.Client.HeaderByNumber(ctx, tag)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"%w: failed to get %s block; the chain may not support block tags: %s",
+			ErrClientInteraction, rpc.BlockNumber(tag.Int64()), err.Error())
+	}
+
+	return header.Number.Uint64(), nil
+}
@@ -18,6 +18,10 @@ const (
 	DefaultOCR3MaxDurationShouldAcceptFinalizedReport  = 20 * time.Millisecond
 	DefaultOCR3MaxDurationShouldTransmitAcceptedReport = 20 * time.Millisecond
 
+	// default OCR2 configuration vars for v2.0 registries where other values match OCR3
+	DefaultOCR2DeltaStage        = 20 * time.Second
+	DefaultOCR2MaxDurationReport = 1200 * time.Millisecond
+
 	// default registry on-chain configuration vars
 	DefaultPaymentPremiumPPB    = uint32(200_000_000)
 	DefaultFlatFeeMicroLink     = uint32(1)
@@ -18,9 +18,9 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	link "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/link_token_interface"
-	bigmath "github.com/smartcontractkit/chainlink/v2/core/utils/big_math"
 
 	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/rpcpool"
 	"github.com/easterthebunny/automation-cli/internal/util"
 )
 
@@ -38,8 +38,7 @@ const (
 	RegistryModeArbitrum = 1
 	RegistryModeOptimism = 2
 
-	gasMultiplier        int64  = 5
-	defaultConfirmations uint16 = 10
+	gasMultiplier int64 = 5
 )
 
 type Deployable interface {
@@ -51,23 +50,25 @@ type Deployer struct {
 	Keys    config.PrivateKeys
 	Config  *config.Environment
 	RPC     *rpc.Client
-	Client  *ethclient.Client
+	Client  *Client
 	Address common.Address
 
 	privateKey *ecdsa.PrivateKey
 	linkToken  *link.LinkToken
+	nonces     *nonceManager
 }
 
 // NewDeployer creates a new deployer and sets the primary address to
 // the address associated with the configured private key.
 func NewDeployer(cfg *config.Environment, key config.Key) (*Deployer, error) {
-	// Created a client by the given node address
-	rpcClient, err := rpc.Dial(cfg.HTTPURL)
+	// Create a client that fails over between all configured node addresses
+	rpcClient, err := rpcpool.Dial(context.Background(), cfg.HTTPEndpoints())
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to connect to chain node (%s): %s", ErrNetworkConnection, cfg.HTTPURL, err.Error())
+		return nil, fmt.Errorf("%w: failed to connect to chain nodes (%s): %s",
+			ErrNetworkConnection, strings.Join(cfg.HTTPEndpoints(), ", "), err.Error())
 	}
 
-	nodeClient := ethclient.NewClient(rpcClient)
+	nodeClient := &Client{Client: ethclient.NewClient(rpcClient)}
 	privateKey := parsePrivateKey(strings.TrimSpace(key.Value))
 
 	address, err := getAddressFromKey(privateKey)
@@ -87,31 +88,29 @@ func NewDeployer(cfg *config.Environment, key config.Key) (*Deployer, error) {
 		}
 	}
 
-	return &Deployer{
+	deployer := &Deployer{
 		Config:     cfg,
 		RPC:        rpcClient,
 		Client:     nodeClient,
 		Address:    address,
 		privateKey: privateKey,
 		linkToken:  token,
-	}, nil
+		nonces:     &nonceManager{},
+	}
+
+	nodeClient.sent = deployer.sent
+
+	return deployer, nil
 }
 
+// BuildTxOpts returns transaction options with the next nonce from the local nonce manager. Transactions built from
+// consecutive calls can be sent before any are mined.
 func (d *Deployer) BuildTxOpts(ctx context.Context) (*bind.TransactOpts, error) {
-	nonce, err := d.Client.PendingNonceAt(ctx, d.Address)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"%w: PendingNonceAt failure for address (%s): %s",
-			ErrClientInteraction, d.Address.Hex(), err.Error())
-	}
-
-	gasPrice, err := d.Client.SuggestGasPrice(ctx)
+	nonce, err := d.nonces.take(ctx, d.Client.Client, d.Address)
 	if err != nil {
-		return nil, fmt.Errorf("%w: SuggestGasPrice failure: %s", ErrClientInteraction, err.Error())
+		return nil, err
 	}
 
-	gasPrice = bigmath.Add(gasPrice, bigmath.Div(gasPrice, big.NewInt(gasMultiplier))) // add 20%
-
 	auth, err := bind.NewKeyedTransactorWithChainID(d.privateKey, big.NewInt(d.Config.ChainID))
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -119,11 +118,23 @@ func (d *Deployer) BuildTxOpts(ctx context.Context) (*bind.TransactOpts, error)
 			ErrClientInteraction, d.Config.ChainID, err.Error())
 	}
 
-	auth.Nonce = big.NewInt(int64(nonce))
+	// the context is passed to the deployer client such that sent transactions are journaled in the environment
+	auth.Context = ctx
+	auth.Signer = d.trackSigner(auth.Signer)
+	auth.Nonce = new(big.Int).SetUint64(nonce)
 	auth.Value = big.NewInt(0) // in wei
 	// auth.GasLimit = d.Config.GasLimit // in units
 	// auth.GasLimit = config.DefaultDeployerGasLimit
-	auth.GasPrice = gasPrice
+
+	if err := d.applyFees(ctx, auth); err != nil {
+		return nil, err
+	}
+
+	if simulating(ctx) {
+		if err := d.applySimulation(ctx, auth); err != nil {
+			return nil, err
+		}
+	}
 
 	return auth, nil
 }
@@ -134,21 +145,17 @@ func (d *Deployer) Send(ctx context.Context, toAddr string, amount *big.Int) err
 		return err
 	}
 
-	addr := common.HexToAddress(toAddr)
-	trx := types.NewTx(&types.LegacyTx{
-		Nonce:    opts.Nonce.Uint64(),
-		To:       &addr,
-		Value:    amount,
-		Gas:      50_000,
-		GasPrice: opts.GasPrice,
-		Data:     nil,
-	})
+	trx := newTransaction(d.Config.ChainID, opts.Nonce.Uint64(), common.HexToAddress(toAddr), amount, 50_000, nil, opts)
 
-	signedTx, err := types.SignTx(trx, types.NewEIP155Signer(big.NewInt(d.Config.ChainID)), d.privateKey)
+	signedTx, err := opts.Signer(d.Address, trx)
 	if err != nil {
 		return fmt.Errorf("failed to sign tx: %w", err)
 	}
 
+	if opts.NoSend {
+		return nil
+	}
+
 	if err = d.Client.SendTransaction(ctx, signedTx); err != nil {
 		return fmt.Errorf("failed to send tx: %w", err)
 	}
@@ -218,34 +225,30 @@ func (d *Deployer) Wait(ctx context.Context, trx *types.Transaction) error {
 }
 
 func (d *Deployer) wait(ctx context.Context, trx *types.Transaction) error {
-	fmt.Println("waiting for transaction to be mined: ", trx.Hash())
-
-	receipt, err := bind.WaitMined(ctx, d.Client, trx)
-	if err != nil {
-		return fmt.Errorf("%w: failed to wait for transaction (%s): %s", ErrChainTransaction, trx.Hash(), err.Error())
-	}
-
-	if receipt.Status == types.ReceiptStatusFailed {
-		var link string
+	_, err := d.waitReceipt(ctx, trx)
 
-		if trx != nil {
-			link = util.ExplorerLink(d.Config.ChainID, trx.Hash())
-		}
+	return err
+}
 
-		var errStr string
+// waitReceipt waits for the transaction to be mined and confirmed and returns the receipt. No receipt is returned in
+// dry run mode.
+func (d *Deployer) waitReceipt(ctx context.Context, trx *types.Transaction) (*types.Receipt, error) {
+	if simulating(ctx) {
+		return nil, nil
+	}
 
-		if err != nil {
-			errStr = err.Error()
-		}
+	fmt.Println("waiting for transaction to be mined: ", trx.Hash())
 
-		return fmt.Errorf("%w: %s: %s", ErrChainTransaction, link, errStr)
+	receipt, err := d.waitMined(ctx, trx)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := waitConfirmations(ctx, d.Client, receipt, defaultConfirmations); err != nil {
-		return err
+	if err := d.waitConfirmations(ctx, receipt); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return receipt, nil
 }
 
 func (d *Deployer) WaitDeployment(ctx context.Context, trx *types.Transaction) error {
@@ -257,16 +260,20 @@ func (d *Deployer) waitDeployment(ctx context.Context, trx *types.Transaction) e
 		return errors.New("tx is not contract creation")
 	}
 
-	receipt, err := bind.WaitMined(ctx, d.Client, trx)
+	if simulating(ctx) {
+		return nil
+	}
+
+	receipt, err := d.waitMined(ctx, trx)
 	if err != nil {
-		return fmt.Errorf("%w: failed to wait for transaction (%s): %s", ErrChainTransaction, trx.Hash(), err.Error())
+		return err
 	}
 
 	if receipt.ContractAddress == (common.Address{}) {
 		return errors.New("zero address")
 	}
 
-	if err := waitConfirmations(ctx, d.Client, receipt, defaultConfirmations); err != nil {
+	if err := d.waitConfirmations(ctx, receipt); err != nil {
 		return err
 	}
 
@@ -281,6 +288,10 @@ func (d *Deployer) waitDeployment(ctx context.Context, trx *types.Transaction) e
 	return nil
 }
 
+func (d *Deployer) explorerLink(trx *types.Transaction) string {
+	return util.ExplorerLink(d.Config.ChainID, trx.Hash())
+}
+
 func parsePrivateKey(encodedKey string) *ecdsa.PrivateKey {
 	pkBase := new(big.Int).SetBytes(common.FromHex(encodedKey))
 	pkX, pkY := crypto.S256().ScalarBaseMult(pkBase.Bytes())
@@ -319,7 +330,7 @@ func (err *jsonError) Error() string {
 	return err.Message
 }
 
-func waitConfirmations(ctx context.Context, client *ethclient.Client, receipt *types.Receipt, confs uint16) error {
+func waitForBlock(ctx context.Context, client *Client, target uint64) error {
 	queryTicker := time.NewTicker(time.Second)
 	defer queryTicker.Stop()
 
@@ -329,11 +340,10 @@ func waitConfirmations(ctx context.Context, client *ethclient.Client, receipt *t
 			return err
 		}
 
-		if block-receipt.BlockNumber.Uint64() >= uint64(confs) {
+		if block >= target {
 			return nil
 		}
 
-		// Wait for the next round.
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -0,0 +1,333 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	bigmath "github.com/smartcontractkit/chainlink/v2/core/utils/big_math"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+var (
+	ErrFeeConfiguration = fmt.Errorf("fee configuration")
+)
+
+const (
+	// baseFeeMultiplier allows the base fee to double before a transaction is priced out of inclusion
+	baseFeeMultiplier int64 = 2
+)
+
+// applyFees sets either a legacy gas price or dynamic fee caps on the transaction options according to the fee
+// strategy of the environment.
+func (d *Deployer) applyFees(ctx context.Context, opts *bind.TransactOpts) error {
+	var fees config.FeeConfig
+
+	if d.Config.Fees != nil {
+		fees = *d.Config.Fees
+	}
+
+	switch fees.Strategy {
+	case config.LegacyFeeStrategy:
+		return d.applyLegacyFees(ctx, opts)
+	case config.DynamicFeeStrategy:
+		header, err := d.Client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("%w: HeaderByNumber failure: %s", ErrClientInteraction, err.Error())
+		}
+
+		if header.BaseFee == nil {
+			return fmt.Errorf("%w: chain does not report a base fee; use the legacy strategy", ErrFeeConfiguration)
+		}
+
+		return d.applyDynamicFees(ctx, opts, fees, header.BaseFee)
+	case config.FixedFeeStrategy:
+		if fees.MaxFeePerGas == 0 || fees.MaxPriorityFeePerGas > fees.MaxFeePerGas {
+			return fmt.Errorf("%w: fixed strategy requires a fee cap not less than the tip cap", ErrFeeConfiguration)
+		}
+
+		opts.GasFeeCap = new(big.Int).SetUint64(fees.MaxFeePerGas)
+		opts.GasTipCap = new(big.Int).SetUint64(fees.MaxPriorityFeePerGas)
+
+		return nil
+	case "":
+		header, err := d.Client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("%w: HeaderByNumber failure: %s", ErrClientInteraction, err.Error())
+		}
+
+		if header.BaseFee == nil {
+			return d.applyLegacyFees(ctx, opts)
+		}
+
+		return d.applyDynamicFees(ctx, opts, fees, header.BaseFee)
+	default:
+		return fmt.Errorf("%w: unknown fee strategy '%s'", ErrFeeConfiguration, fees.Strategy)
+	}
+}
+
+func (d *Deployer) applyLegacyFees(ctx context.Context, opts *bind.TransactOpts) error {
+	gasPrice, err := d.Client.SuggestGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: SuggestGasPrice failure: %s", ErrClientInteraction, err.Error())
+	}
+
+	opts.GasPrice = bigmath.Add(gasPrice, bigmath.Div(gasPrice, big.NewInt(gasMultiplier))) // add 20%
+
+	return nil
+}
+
+func (d *Deployer) applyDynamicFees(
+	ctx context.Context,
+	opts *bind.TransactOpts,
+	fees config.FeeConfig,
+	baseFee *big.Int,
+) error {
+	tipCap, err := d.Client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: SuggestGasTipCap failure: %s", ErrClientInteraction, err.Error())
+	}
+
+	feeCap := bigmath.Add(bigmath.Mul(baseFee, big.NewInt(baseFeeMultiplier)), tipCap)
+
+	if fees.MaxFeePerGas > 0 {
+		maxFee := new(big.Int).SetUint64(fees.MaxFeePerGas)
+
+		feeCap = bigmath.Min(feeCap, maxFee)
+		tipCap = bigmath.Min(tipCap, feeCap)
+	}
+
+	opts.GasFeeCap = feeCap
+	opts.GasTipCap = tipCap
+
+	return nil
+}
+
+const (
+	defaultInclusionTimeout = 3 * time.Minute
+	defaultBumpPercent      = uint64(20)
+	// minBumpPercent is the smallest fee increase nodes accept for a replacement transaction
+	minBumpPercent = uint64(10)
+	// defaultBumpCeilingMultiplier limits bumped fees relative to the original when no ceiling is configured
+	defaultBumpCeilingMultiplier int64 = 10
+	cancelGasLimit                     = uint64(21_000)
+)
+
+type bumpConfig struct {
+	timeout time.Duration
+	percent uint64
+	ceiling *big.Int
+}
+
+// bumpConfigFor returns the fee bump settings of the environment where the ceiling defaults to a multiple of the fees
+// of the original transaction.
+func (d *Deployer) bumpConfigFor(original *types.Transaction) bumpConfig {
+	conf := bumpConfig{
+		timeout: defaultInclusionTimeout,
+		percent: defaultBumpPercent,
+		ceiling: bigmath.Mul(feeOf(original), big.NewInt(defaultBumpCeilingMultiplier)),
+	}
+
+	if d.Config.Fees == nil {
+		return conf
+	}
+
+	if d.Config.Fees.InclusionTimeout > 0 {
+		conf.timeout = d.Config.Fees.InclusionTimeout
+	}
+
+	if d.Config.Fees.BumpPercent > 0 {
+		conf.percent = d.Config.Fees.BumpPercent
+	}
+
+	if conf.percent < minBumpPercent {
+		conf.percent = minBumpPercent
+	}
+
+	if d.Config.Fees.BumpCeiling > 0 {
+		conf.ceiling = new(big.Int).SetUint64(d.Config.Fees.BumpCeiling)
+	}
+
+	return conf
+}
+
+// replaceTransaction signs and sends a copy of the transaction with fees bumped by the configured percentage. Nil is
+// returned without error when fees cannot be bumped further without passing the ceiling.
+func (d *Deployer) replaceTransaction(
+	ctx context.Context,
+	trx *types.Transaction,
+	conf bumpConfig,
+) (*types.Transaction, error) {
+	replacement, ok := bumpedTransaction(trx, conf.percent, conf.ceiling)
+	if !ok {
+		return nil, nil
+	}
+
+	// the nonce manager tracks the replacement as the latest transaction for the nonce
+	signedTx, err := d.trackSigner(d.signTx)(d.Address, replacement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign tx: %w", err)
+	}
+
+	if simulating(ctx) {
+		d.simulate(ctx, d.Address, signedTx)
+
+		return signedTx, nil
+	}
+
+	if err := d.Client.SendTransaction(ctx, signedTx); err != nil {
+		return nil, fmt.Errorf("%w: failed to send replacement for (%s): %s", ErrChainTransaction, trx.Hash(), err.Error())
+	}
+
+	fmt.Printf("replaced transaction %s with %s at nonce %d\n", trx.Hash(), signedTx.Hash(), signedTx.Nonce())
+
+	return signedTx, nil
+}
+
+// cancelTransaction replaces the transaction at the nonce with an empty transfer to the deployer. When the original
+// transaction is known, fees are bumped from the original. Otherwise, currently suggested fees are bumped.
+func (d *Deployer) cancelTransaction(
+	ctx context.Context,
+	nonce uint64,
+	original *types.Transaction,
+) (*types.Transaction, error) {
+	if original == nil {
+		opts := &bind.TransactOpts{}
+
+		if err := d.applyFees(ctx, opts); err != nil {
+			return nil, err
+		}
+
+		original = newTransaction(d.Config.ChainID, nonce, d.Address, big.NewInt(0), cancelGasLimit, nil, opts)
+	}
+
+	conf := d.bumpConfigFor(original)
+
+	opts := &bind.TransactOpts{
+		GasPrice:  original.GasPrice(),
+		GasFeeCap: original.GasFeeCap(),
+		GasTipCap: original.GasTipCap(),
+	}
+
+	if original.Type() == types.LegacyTxType {
+		opts.GasFeeCap = nil
+		opts.GasTipCap = nil
+	}
+
+	empty := newTransaction(d.Config.ChainID, nonce, d.Address, big.NewInt(0), cancelGasLimit, nil, opts)
+
+	replacement, err := d.replaceTransaction(ctx, empty, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if replacement == nil {
+		return nil, fmt.Errorf("%w: fees for nonce %d are at the ceiling", ErrChainTransaction, nonce)
+	}
+
+	return replacement, nil
+}
+
+// newTransaction builds an unsigned transaction with the fees of the provided transaction options. A dynamic fee
+// transaction is built when a fee cap is set.
+func newTransaction(
+	chainID int64,
+	nonce uint64,
+	to common.Address,
+	value *big.Int,
+	gas uint64,
+	data []byte,
+	opts *bind.TransactOpts,
+) *types.Transaction {
+	if opts.GasFeeCap != nil {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   big.NewInt(chainID),
+			Nonce:     nonce,
+			To:        &to,
+			Value:     value,
+			Gas:       gas,
+			GasFeeCap: opts.GasFeeCap,
+			GasTipCap: opts.GasTipCap,
+			Data:      data,
+		})
+	}
+
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		To:       &to,
+		Value:    value,
+		Gas:      gas,
+		GasPrice: opts.GasPrice,
+		Data:     data,
+	})
+}
+
+// bumpedTransaction returns an unsigned copy of the transaction with fees increased by the percentage and limited by
+// the ceiling. False is returned when the limited fees are not high enough to replace the original.
+func bumpedTransaction(trx *types.Transaction, percent uint64, ceiling *big.Int) (*types.Transaction, bool) {
+	minimum := bumpBy(feeOf(trx), minBumpPercent)
+	if ceiling != nil && ceiling.Cmp(minimum) < 0 {
+		return nil, false
+	}
+
+	switch trx.Type() {
+	case types.LegacyTxType:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    trx.Nonce(),
+			To:       trx.To(),
+			Value:    trx.Value(),
+			Gas:      trx.Gas(),
+			GasPrice: limit(bumpBy(trx.GasPrice(), percent), ceiling),
+			Data:     trx.Data(),
+		}), true
+	case types.DynamicFeeTxType:
+		feeCap := limit(bumpBy(trx.GasFeeCap(), percent), ceiling)
+
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    trx.ChainId(),
+			Nonce:      trx.Nonce(),
+			To:         trx.To(),
+			Value:      trx.Value(),
+			Gas:        trx.Gas(),
+			GasFeeCap:  feeCap,
+			GasTipCap:  bigmath.Min(bumpBy(trx.GasTipCap(), percent), feeCap),
+			Data:       trx.Data(),
+			AccessList: trx.AccessList(),
+		}), true
+	default:
+		return nil, false
+	}
+}
+
+// feeOf returns the gas price of legacy transactions and the fee cap of dynamic fee transactions.
+func feeOf(trx *types.Transaction) *big.Int {
+	if trx.Type() == types.LegacyTxType {
+		return trx.GasPrice()
+	}
+
+	return trx.GasFeeCap()
+}
+
+func bumpBy(value *big.Int, percent uint64) *big.Int {
+	increase := bigmath.Div(bigmath.Mul(value, new(big.Int).SetUint64(percent)), big.NewInt(100))
+
+	// always increase by at least 1 wei such that small values can be replaced
+	if increase.Sign() == 0 {
+		increase = big.NewInt(1)
+	}
+
+	return bigmath.Add(value, increase)
+}
+
+func limit(value, ceiling *big.Int) *big.Int {
+	if ceiling == nil {
+		return value
+	}
+
+	return bigmath.Min(value, ceiling)
+}
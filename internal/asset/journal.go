@@ -0,0 +1,111 @@
+package asset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/easterthebunny/automation-cli/internal/decode"
+	"github.com/easterthebunny/automation-cli/internal/journal"
+)
+
+// recordPending adds a sent transaction to the environment journal such that it is recorded even when the outcome is
+// never known, for example when the command fails or is interrupted before waiting on the transaction.
+func (d *Deployer) recordPending(ctx context.Context, trx *types.Transaction) {
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(d.Config.ChainID)), trx)
+	if err != nil {
+		fmt.Println("failed to record transaction in journal: ", err.Error())
+
+		return
+	}
+
+	entry := journal.Entry{
+		Hash:   trx.Hash().Hex(),
+		Nonce:  trx.Nonce(),
+		From:   from.Hex(),
+		Method: decode.MethodName(trx),
+		Status: journal.StatusPending,
+	}
+
+	if trx.To() != nil {
+		entry.To = trx.To().Hex()
+	}
+
+	writeJournal(ctx, entry)
+}
+
+// record adds the outcome of waiting on a transaction to the environment journal. The mined attempt is recorded
+// with its receipt and the other attempts are recorded as replaced. Transactions that lost their nonce to another
+// transaction are recorded as replaced and transactions that are still pending keep their pending entry.
+func (d *Deployer) record(
+	ctx context.Context,
+	from common.Address,
+	attempts []*types.Transaction,
+	receipt *types.Receipt,
+	waitErr error,
+) {
+	trx := attempts[len(attempts)-1]
+
+	entry := journal.Entry{
+		Hash:   trx.Hash().Hex(),
+		Nonce:  trx.Nonce(),
+		From:   from.Hex(),
+		Method: decode.MethodName(trx),
+	}
+
+	switch {
+	case receipt != nil:
+		for _, attempt := range attempts {
+			if attempt.Hash() == receipt.TxHash {
+				trx = attempt
+
+				continue
+			}
+
+			replaced := entry
+			replaced.Hash = attempt.Hash().Hex()
+			replaced.Status = journal.StatusReplaced
+
+			if attempt.To() != nil {
+				replaced.To = attempt.To().Hex()
+			}
+
+			writeJournal(ctx, replaced)
+		}
+
+		entry.Hash = receipt.TxHash.Hex()
+		entry.Method = decode.MethodName(trx)
+		entry.GasUsed = receipt.GasUsed
+		entry.Status = journal.StatusSuccess
+
+		if receipt.BlockNumber != nil {
+			entry.Block = receipt.BlockNumber.Uint64()
+		}
+
+		if receipt.Status == types.ReceiptStatusFailed {
+			entry.Status = journal.StatusFailed
+		}
+	case errors.Is(waitErr, ErrTransactionReplaced):
+		entry.Status = journal.StatusReplaced
+	default:
+		return
+	}
+
+	if trx.To() != nil {
+		entry.To = trx.To().Hex()
+	} else if receipt != nil {
+		entry.To = receipt.ContractAddress.Hex()
+	}
+
+	writeJournal(ctx, entry)
+}
+
+func writeJournal(ctx context.Context, entry journal.Entry) {
+	if err := journal.Record(ctx, entry); err != nil {
+		fmt.Println("failed to record transaction in journal: ", err.Error())
+	}
+}
@@ -0,0 +1,366 @@
+package asset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var (
+	ErrTransactionReplaced = fmt.Errorf("transaction replaced")
+)
+
+const (
+	// receiptPollInterval is the time between receipt queries while waiting for a transaction to be mined
+	receiptPollInterval = time.Second
+)
+
+// nonceManager hands out sequential nonces for a single account such that multiple transactions can be submitted
+// before any of them are mined. A nonce that was handed out but never signed is handed out again on the next request
+// to avoid leaving a gap when building a transaction fails before it is sent. Signed transactions are kept until the
+// chain pending nonce passes them such that a nonce whose transaction a node rejected is handed out again.
+type nonceManager struct {
+	mu       sync.Mutex
+	synced   bool
+	next     uint64
+	unsigned bool
+	signed   map[uint64]signedNonce
+}
+
+// signedNonce is the latest transaction signed for a nonce. Sent is set once any transaction with the nonce was
+// accepted by a node and rejected is set when the latest transaction failed to send while none were accepted.
+type signedNonce struct {
+	hash     common.Hash
+	sent     bool
+	rejected bool
+}
+
+func (m *nonceManager) take(ctx context.Context, client *ethclient.Client, addr common.Address) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending, err := client.PendingNonceAt(ctx, addr)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"%w: PendingNonceAt failure for address (%s): %s",
+			ErrClientInteraction, addr.Hex(), err.Error())
+	}
+
+	for nonce := range m.signed {
+		if nonce < pending {
+			delete(m.signed, nonce)
+		}
+	}
+
+	// the chain is ahead when another process sent transactions from the same account
+	if !m.synced || pending > m.next {
+		m.next = pending
+		m.synced = true
+		m.unsigned = false
+	}
+
+	// a rejected transaction leaves a gap that every later transaction is queued behind
+	if nonce, ok := m.lowestRejected(); ok {
+		return nonce, nil
+	}
+
+	if m.unsigned {
+		return m.next - 1, nil
+	}
+
+	nonce := m.next
+
+	m.next++
+	m.unsigned = true
+
+	return nonce, nil
+}
+
+func (m *nonceManager) lowestRejected() (uint64, bool) {
+	var (
+		lowest uint64
+		found  bool
+	)
+
+	for nonce, signed := range m.signed {
+		if signed.rejected && (!found || nonce < lowest) {
+			lowest = nonce
+			found = true
+		}
+	}
+
+	return lowest, found
+}
+
+// sign marks a nonce as used by a signed transaction.
+func (m *nonceManager) sign(nonce uint64, hash common.Hash) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.signed == nil {
+		m.signed = make(map[uint64]signedNonce)
+	}
+
+	m.signed[nonce] = signedNonce{hash: hash, sent: m.signed[nonce].sent}
+
+	if m.synced && nonce+1 == m.next {
+		m.unsigned = false
+	}
+}
+
+// send records the result of sending a signed transaction. Only a failed send of the latest transaction for a nonce
+// that no node accepted marks the nonce to be handed out again. A nonce that a node accepted is never handed out
+// again since the transaction may still be mined even when other nodes do not know about it.
+func (m *nonceManager) send(trx *types.Transaction, sendErr error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	signed, ok := m.signed[trx.Nonce()]
+	if !ok || signed.hash != trx.Hash() {
+		return
+	}
+
+	if sendErr == nil {
+		signed.sent = true
+		signed.rejected = false
+	} else if !signed.sent {
+		signed.rejected = true
+	}
+
+	m.signed[trx.Nonce()] = signed
+}
+
+// reset forces the next nonce to be read from the chain.
+func (m *nonceManager) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.synced = false
+	m.unsigned = false
+	m.signed = nil
+}
+
+// trackSigner wraps the transaction signer to record each nonce that is signed.
+func (d *Deployer) trackSigner(signer bind.SignerFn) bind.SignerFn {
+	return func(addr common.Address, trx *types.Transaction) (*types.Transaction, error) {
+		signedTx, err := signer(addr, trx)
+		if err != nil {
+			return nil, err
+		}
+
+		d.nonces.sign(signedTx.Nonce(), signedTx.Hash())
+
+		return signedTx, nil
+	}
+}
+
+// signTx signs the transaction with the deployer key.
+func (d *Deployer) signTx(_ common.Address, trx *types.Transaction) (*types.Transaction, error) {
+	return types.SignTx(trx, types.LatestSignerForChainID(big.NewInt(d.Config.ChainID)), d.privateKey)
+}
+
+// sent is called by the deployer client after each transaction it sends. Transactions that a node accepted are
+// recorded as pending in the environment transaction journal.
+func (d *Deployer) sent(ctx context.Context, trx *types.Transaction, err error) {
+	d.nonces.send(trx, err)
+
+	if err == nil {
+		d.recordPending(ctx, trx)
+	}
+}
+
+// WaitAll waits for all transactions to be mined and then waits for confirmations once for the latest receipt. All
+// transactions are waited on even when some fail and all failures are returned together.
+func (d *Deployer) WaitAll(ctx context.Context, trxs ...*types.Transaction) error {
+	if simulating(ctx) {
+		return nil
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		errs     []error
+		receipts []*types.Receipt
+	)
+
+	for _, trx := range trxs {
+		wg.Add(1)
+
+		go func(trx *types.Transaction) {
+			defer wg.Done()
+
+			receipt, err := d.waitMined(ctx, trx)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs = append(errs, err)
+
+				return
+			}
+
+			receipts = append(receipts, receipt)
+		}(trx)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	var latest *types.Receipt
+
+	for _, receipt := range receipts {
+		if latest == nil || receipt.BlockNumber.Cmp(latest.BlockNumber) > 0 {
+			latest = receipt
+		}
+	}
+
+	if latest == nil {
+		return nil
+	}
+
+	return d.waitConfirmations(ctx, latest)
+}
+
+// waitMined polls for the transaction receipt and returns an error if the receipt status is failed. A transaction
+// sent by the deployer that is not mined within the inclusion timeout is resubmitted with bumped fees and the receipts
+// of all submitted versions are watched. A transaction that disappears from the mempool is broadcast again unless its
+// nonce was used by a different transaction, in which case the transaction was replaced and the nonce manager is
+// resynced from the chain.
+func (d *Deployer) waitMined(ctx context.Context, trx *types.Transaction) (*types.Receipt, error) {
+	return d.waitAny(ctx, []*types.Transaction{trx})
+}
+
+// waitAny waits for any of the provided transactions with the same nonce to be mined. The last transaction is the
+// most recent replacement and is the one that gets replaced on an inclusion timeout. The outcome is recorded in the
+// environment transaction journal when known.
+func (d *Deployer) waitAny(ctx context.Context, attempts []*types.Transaction) (receipt *types.Receipt, err error) {
+	queryTicker := time.NewTicker(receiptPollInterval)
+	defer queryTicker.Stop()
+
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(d.Config.ChainID)), attempts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to recover sender: %s", ErrChainTransaction, err.Error())
+	}
+
+	defer func() {
+		d.record(ctx, from, attempts, receipt, err)
+	}()
+
+	var (
+		bump       = d.bumpConfigFor(attempts[0])
+		lastSubmit = time.Now()
+		atCeiling  bool
+	)
+
+	for {
+		receipt, mined, err := d.attemptReceipt(ctx, attempts)
+		if err != nil {
+			return receipt, err
+		}
+
+		if mined {
+			return receipt, nil
+		}
+
+		latest := attempts[len(attempts)-1]
+
+		if err := d.recoverMissing(ctx, from, latest); err != nil {
+			// one of the earlier attempts may be the transaction that used the nonce
+			if receipt, mined, rErr := d.attemptReceipt(ctx, attempts); mined || rErr != nil {
+				return receipt, rErr
+			}
+
+			return nil, err
+		}
+
+		if from == d.Address && !atCeiling && time.Since(lastSubmit) > bump.timeout {
+			replacement, err := d.replaceTransaction(ctx, latest, bump)
+			if err != nil {
+				return nil, err
+			}
+
+			if replacement == nil {
+				fmt.Println("transaction fees are at the configured ceiling; waiting without further replacement: ", latest.Hash())
+
+				atCeiling = true
+			} else {
+				attempts = append(attempts, replacement)
+			}
+
+			lastSubmit = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-queryTicker.C:
+		}
+	}
+}
+
+// attemptReceipt returns the receipt of the first mined transaction in the list.
+func (d *Deployer) attemptReceipt(ctx context.Context, attempts []*types.Transaction) (*types.Receipt, bool, error) {
+	for _, attempt := range attempts {
+		receipt, err := d.Client.TransactionReceipt(ctx, attempt.Hash())
+		if err == nil {
+			if receipt.Status == types.ReceiptStatusFailed {
+				return receipt, true, fmt.Errorf("%w: %s", ErrChainTransaction, d.explorerLink(attempt))
+			}
+
+			return receipt, true, nil
+		}
+
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, false, fmt.Errorf(
+				"%w: failed to get receipt (%s): %s", ErrChainTransaction, attempt.Hash(), err.Error())
+		}
+	}
+
+	return nil, false, nil
+}
+
+func (d *Deployer) recoverMissing(ctx context.Context, from common.Address, trx *types.Transaction) error {
+	_, _, err := d.Client.TransactionByHash(ctx, trx.Hash())
+	if err == nil || !errors.Is(err, ethereum.NotFound) {
+		// the transaction is known to the node or the node could not be reached; keep polling in both cases
+		return nil
+	}
+
+	confirmed, err := d.Client.NonceAt(ctx, from, nil)
+	if err != nil {
+		return fmt.Errorf("%w: NonceAt failure for address (%s): %s", ErrClientInteraction, from.Hex(), err.Error())
+	}
+
+	if confirmed > trx.Nonce() {
+		// the receipt may not be indexed yet so check one last time before reporting a replacement
+		if _, err := d.Client.TransactionReceipt(ctx, trx.Hash()); err == nil {
+			return nil
+		}
+
+		d.nonces.reset()
+
+		return fmt.Errorf(
+			"%w: nonce %d of (%s) was used by another transaction", ErrTransactionReplaced, trx.Nonce(), trx.Hash())
+	}
+
+	fmt.Println("transaction dropped from mempool; broadcasting again: ", trx.Hash())
+
+	if err := d.Client.SendTransaction(ctx, trx); err != nil {
+		fmt.Println("failed to broadcast transaction: ", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrNonceNotPending = fmt.Errorf("nonce not pending")
+)
+
+type txPoolContent struct {
+	Pending map[string]*types.Transaction `json:"pending"`
+	Queued  map[string]*types.Transaction `json:"queued"`
+}
+
+// PendingTransaction returns the transaction from the deployer waiting in the mempool at the provided nonce. An error
+// is returned if the nonce was already mined. Nil is returned without error if the node does not expose its
+// transaction pool or the transaction is not known to the node.
+func (d *Deployer) PendingTransaction(ctx context.Context, nonce uint64) (*types.Transaction, error) {
+	confirmed, err := d.Client.NonceAt(ctx, d.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"%w: NonceAt failure for address (%s): %s", ErrClientInteraction, d.Address.Hex(), err.Error())
+	}
+
+	if nonce < confirmed {
+		return nil, fmt.Errorf("%w: nonce %d was already mined for %s", ErrNonceNotPending, nonce, d.Address)
+	}
+
+	var content txPoolContent
+
+	if err := d.RPC.CallContext(ctx, &content, "txpool_contentFrom", d.Address); err != nil {
+		fmt.Println("transaction pool not available from node: ", err.Error())
+
+		return nil, nil
+	}
+
+	key := strconv.FormatUint(nonce, 10)
+
+	if trx, ok := content.Pending[key]; ok {
+		return trx, nil
+	}
+
+	if trx, ok := content.Queued[key]; ok {
+		return trx, nil
+	}
+
+	return nil, nil
+}
+
+// TransactionByHash returns a pending transaction from the deployer by hash.
+func (d *Deployer) TransactionByHash(ctx context.Context, hash string) (*types.Transaction, error) {
+	bts, err := hexutil.Decode(hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash: %w", err)
+	}
+
+	trx, pending, err := d.Client.TransactionByHash(ctx, common.BytesToHash(bts))
+	if err != nil {
+		return nil, fmt.Errorf("%w: TransactionByHash failure: %s", ErrClientInteraction, err.Error())
+	}
+
+	if !pending {
+		return nil, fmt.Errorf("%w: transaction %s was already mined", ErrNonceNotPending, hash)
+	}
+
+	return trx, nil
+}
+
+// SpeedUp replaces the pending transaction with a copy using fees bumped by the configured percentage and waits for
+// either version to be mined.
+func (d *Deployer) SpeedUp(ctx context.Context, trx *types.Transaction) (*types.Transaction, error) {
+	replacement, err := d.replaceTransaction(ctx, trx, d.bumpConfigFor(trx))
+	if err != nil {
+		return nil, err
+	}
+
+	if replacement == nil {
+		return nil, fmt.Errorf("%w: fees for nonce %d are at the ceiling", ErrChainTransaction, trx.Nonce())
+	}
+
+	return replacement, d.waitReplaced(ctx, trx, replacement)
+}
+
+// Cancel replaces the transaction at the pending nonce with an empty transfer to the deployer and waits for it to be
+// mined. The original transaction is used to price the replacement when it is provided.
+func (d *Deployer) Cancel(ctx context.Context, nonce uint64, original *types.Transaction) (*types.Transaction, error) {
+	replacement, err := d.cancelTransaction(ctx, nonce, original)
+	if err != nil {
+		return nil, err
+	}
+
+	if original == nil {
+		return replacement, d.wait(ctx, replacement)
+	}
+
+	return replacement, d.waitReplaced(ctx, original, replacement)
+}
+
+// waitReplaced waits for either the original or the replacement to be mined and reports which one was included.
+func (d *Deployer) waitReplaced(ctx context.Context, original, replacement *types.Transaction) error {
+	if simulating(ctx) {
+		return nil
+	}
+
+	fmt.Println("waiting for transaction to be mined: ", replacement.Hash())
+
+	receipt, err := d.waitAny(ctx, []*types.Transaction{original, replacement})
+	if err != nil {
+		return err
+	}
+
+	if receipt.TxHash == original.Hash() {
+		fmt.Println("original transaction was mined before the replacement: ", original.Hash())
+	}
+
+	return d.waitConfirmations(ctx, receipt)
+}
@@ -0,0 +1,45 @@
+package asset
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+// RegistrarDeployable is a registrar contract of any supported version that can be deployed, connected to, and used
+// to register upkeeps. Request approval and trigger configuration are only available for v2.1.
+type RegistrarDeployable interface {
+	Connect(context.Context, *Deployer) (common.Address, error)
+	Deploy(context.Context, *Deployer) (common.Address, error)
+	RegisterUpkeep(context.Context, *Deployer, RegistrationParams) (RegistrationResult, error)
+}
+
+// NewRegistrarDeployable returns the registrar deployable for the version recorded in the registrar configuration.
+func NewRegistrarDeployable(
+	link config.LinkTokenContract,
+	registry config.AutomationRegistryV21Contract,
+	cCfg *config.AutomationRegistrarV21Contract,
+) (RegistrarDeployable, error) {
+	switch version := cCfg.ContractVersion(); version {
+	case config.RegistryVersion20:
+		return NewRegistrarV20Deployable(link, registry, cCfg), nil
+	case config.RegistryVersion21:
+		return NewRegistrarV21Deployable(link, registry, cCfg), nil
+	default:
+		return nil, unsupportedVersion(version)
+	}
+}
+
+// NewDefaultRegistrarConfig returns the default registrar configuration for a registry of the provided version.
+func NewDefaultRegistrarConfig(version string) (*config.AutomationRegistrarV21Contract, error) {
+	switch version {
+	case config.RegistryVersion20:
+		return NewDefaultRegistrarV20Config(), nil
+	case config.RegistryVersion21:
+		return NewDefaultRegistrarV21Config(), nil
+	default:
+		return nil, unsupportedVersion(version)
+	}
+}
@@ -0,0 +1,216 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	registrar20 "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/keeper_registrar_wrapper2_0"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+// RegistrarV20Deployable is the registrar for a v2.0 registry. A v2.0 registrar only registers conditional upkeeps
+// and has a single auto-approval configuration.
+type RegistrarV20Deployable struct {
+	contract *registrar20.KeeperRegistrar
+	link     config.LinkTokenContract
+	registry config.AutomationRegistryV21Contract
+	cCfg     *config.AutomationRegistrarV21Contract
+}
+
+func NewRegistrarV20Deployable(
+	link config.LinkTokenContract,
+	registry config.AutomationRegistryV21Contract,
+	cCfg *config.AutomationRegistrarV21Contract,
+) *RegistrarV20Deployable {
+	return &RegistrarV20Deployable{
+		link:     link,
+		registry: registry,
+		cCfg:     cCfg,
+	}
+}
+
+// NewDefaultRegistrarV20Config returns a registrar configuration that auto-approves up to 1000 conditional upkeep
+// registrations.
+func NewDefaultRegistrarV20Config() *config.AutomationRegistrarV21Contract {
+	return &config.AutomationRegistrarV21Contract{
+		Type:    config.AutomationRegistrarContractType,
+		Version: config.RegistryVersion20,
+		MinLink: 0,
+		AutoApprovals: []config.AutomationRegistrarV21AutoApprovalConfig{
+			{
+				TriggerType:           0,
+				AutoApproveType:       2,
+				AutoApproveMaxAllowed: 1_000,
+			},
+		},
+	}
+}
+
+func (d *RegistrarV20Deployable) Connect(ctx context.Context, deployer *Deployer) (common.Address, error) {
+	return d.connectToInterface(ctx, common.HexToAddress(d.cCfg.Address), deployer)
+}
+
+func (d *RegistrarV20Deployable) Deploy(ctx context.Context, deployer *Deployer) (common.Address, error) {
+	var contractAddr common.Address
+
+	approval, err := d.autoApproval()
+	if err != nil {
+		return contractAddr, err
+	}
+
+	opts, err := deployer.BuildTxOpts(ctx)
+	if err != nil {
+		return contractAddr, fmt.Errorf("%w: deploy failed: %s", ErrContractCreate, err.Error())
+	}
+
+	contractAddr, trx, _, err := registrar20.DeployKeeperRegistrar(
+		opts, deployer.Client,
+		common.HexToAddress(d.link.Address), approval.AutoApproveType, uint16(approval.AutoApproveMaxAllowed),
+		common.HexToAddress(d.registry.Address), big.NewInt(d.cCfg.MinLink),
+	)
+	if err != nil {
+		return contractAddr, fmt.Errorf("%w: KeeperRegistrar creation failed: %s", ErrContractCreate, err.Error())
+	}
+
+	if err := deployer.waitDeployment(ctx, trx); err != nil {
+		return contractAddr, err
+	}
+
+	d.cCfg.Address = contractAddr.Hex()
+
+	return contractAddr, nil
+}
+
+// RegisterUpkeep sends the registration request and LINK amount to the registrar in a single LINK transferAndCall and
+// reads the request hash and upkeep id from the receipt logs. Only conditional upkeeps can be registered.
+func (d *RegistrarV20Deployable) RegisterUpkeep(
+	ctx context.Context,
+	deployer *Deployer,
+	params RegistrationParams,
+) (RegistrationResult, error) {
+	var result RegistrationResult
+
+	if params.TriggerType != 0 || len(params.TriggerConfig) > 0 {
+		return result, fmt.Errorf("%w: a v2.0 registrar only registers conditional upkeeps", ErrConfiguration)
+	}
+
+	if deployer.linkToken == nil {
+		return result, fmt.Errorf("%w: link token required to register upkeeps", ErrConfiguration)
+	}
+
+	registrarABI, err := registrar20.KeeperRegistrarMetaData.GetAbi()
+	if err != nil {
+		return result, fmt.Errorf("%w: failed to parse registrar abi: %s", ErrConfiguration, err.Error())
+	}
+
+	data, err := registrarABI.Pack(
+		"register",
+		params.Name, params.EncryptedEmail, params.UpkeepContract, params.GasLimit, params.AdminAddress,
+		params.CheckData, params.OffchainConfig, params.Amount, deployer.Address,
+	)
+	if err != nil {
+		return result, fmt.Errorf("%w: failed to encode registration: %s", ErrConfiguration, err.Error())
+	}
+
+	opts, err := deployer.BuildTxOpts(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	trx, err := deployer.linkToken.TransferAndCall(opts, common.HexToAddress(d.cCfg.Address), params.Amount, data)
+	if err != nil {
+		return result, fmt.Errorf("%w: registration failed: %s", ErrContractConnection, err.Error())
+	}
+
+	receipt, err := deployer.waitReceipt(ctx, trx)
+	if err != nil || receipt == nil {
+		return result, err
+	}
+
+	return d.registrationResult(receipt)
+}
+
+// autoApproval returns the conditional trigger auto-approval configuration, which is the only configuration a v2.0
+// registrar supports.
+func (d *RegistrarV20Deployable) autoApproval() (config.AutomationRegistrarV21AutoApprovalConfig, error) {
+	var approval config.AutomationRegistrarV21AutoApprovalConfig
+
+	for _, conf := range d.cCfg.AutoApprovals {
+		switch {
+		case conf.TriggerType != 0:
+			return approval, fmt.Errorf("%w: a v2.0 registrar does not support trigger type %d",
+				ErrConfiguration, conf.TriggerType)
+		case conf.AutoApproveMaxAllowed > math.MaxUint16:
+			return approval, fmt.Errorf("%w: a v2.0 registrar allows at most %d auto-approvals",
+				ErrConfiguration, math.MaxUint16)
+		}
+
+		approval = conf
+	}
+
+	return approval, nil
+}
+
+func (d *RegistrarV20Deployable) connectToInterface(
+	_ context.Context,
+	addr common.Address,
+	deployer *Deployer,
+) (common.Address, error) {
+	contract, err := registrar20.NewKeeperRegistrar(addr, deployer.Client)
+	if err != nil {
+		return addr, fmt.Errorf("%w: failed to connect to contract at (%s): %s", ErrContractConnection, addr, err.Error())
+	}
+
+	d.contract = contract
+
+	return addr, nil
+}
+
+func (d *RegistrarV20Deployable) registrationResult(receipt *types.Receipt) (RegistrationResult, error) {
+	var result RegistrationResult
+
+	registrarABI, err := registrar20.KeeperRegistrarMetaData.GetAbi()
+	if err != nil {
+		return result, fmt.Errorf("%w: failed to parse registrar abi: %s", ErrConfiguration, err.Error())
+	}
+
+	var requested bool
+
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 || log.Address != common.HexToAddress(d.cCfg.Address) {
+			continue
+		}
+
+		switch log.Topics[0] {
+		case registrarABI.Events["RegistrationRequested"].ID:
+			event, err := d.contract.ParseRegistrationRequested(*log)
+			if err != nil {
+				return result, fmt.Errorf("%w: failed to decode RegistrationRequested: %s", ErrContractConnection, err.Error())
+			}
+
+			requested = true
+			result.Hash = event.Hash
+		case registrarABI.Events["RegistrationApproved"].ID:
+			event, err := d.contract.ParseRegistrationApproved(*log)
+			if err != nil {
+				return result, fmt.Errorf("%w: failed to decode RegistrationApproved: %s", ErrContractConnection, err.Error())
+			}
+
+			result.Hash = event.Hash
+			result.Approved = true
+			result.UpkeepID = event.UpkeepId
+		}
+	}
+
+	if !requested && !result.Approved {
+		return result, fmt.Errorf("%w: no registration events in transaction %s", ErrContractConnection, receipt.TxHash)
+	}
+
+	return result, nil
+}
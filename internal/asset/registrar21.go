@@ -7,6 +7,7 @@ import (
 
 	"github.com/easterthebunny/automation-cli/internal/config"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	registrar "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/automation_registrar_wrapper2_1"
 )
 
@@ -29,6 +30,28 @@ func NewRegistrarV21Deployable(
 	}
 }
 
+// NewDefaultRegistrarV21Config returns a registrar configuration that auto-approves up to 1000 registrations for
+// both conditional and log trigger upkeeps.
+func NewDefaultRegistrarV21Config() *config.AutomationRegistrarV21Contract {
+	return &config.AutomationRegistrarV21Contract{
+		Type:    config.AutomationRegistrarContractType,
+		Version: config.RegistryVersion21,
+		MinLink: 0,
+		AutoApprovals: []config.AutomationRegistrarV21AutoApprovalConfig{
+			{
+				TriggerType:           0,
+				AutoApproveType:       2,
+				AutoApproveMaxAllowed: 1_000,
+			},
+			{
+				TriggerType:           1,
+				AutoApproveType:       2,
+				AutoApproveMaxAllowed: 1_000,
+			},
+		},
+	}
+}
+
 func (d *RegistrarV21Deployable) Connect(ctx context.Context, deployer *Deployer) (common.Address, error) {
 	return d.connectToInterface(ctx, common.HexToAddress(d.cCfg.Address), deployer)
 }
@@ -97,3 +120,116 @@ func (d *RegistrarV21Deployable) connectToInterface(
 
 	return addr, nil
 }
+
+// RegistrationParams describes an upkeep to register through the registrar. The amount is in juels of LINK.
+type RegistrationParams struct {
+	Name           string
+	EncryptedEmail []byte
+	UpkeepContract common.Address
+	GasLimit       uint32
+	AdminAddress   common.Address
+	TriggerType    uint8
+	CheckData      []byte
+	TriggerConfig  []byte
+	OffchainConfig []byte
+	Amount         *big.Int
+}
+
+// RegistrationResult is the outcome of a registration request. Requests that are not auto-approved remain pending
+// under the request hash until they are approved or cancelled.
+type RegistrationResult struct {
+	Hash     common.Hash
+	Approved bool
+	UpkeepID *big.Int
+}
+
+// RegisterUpkeep sends the registration request and LINK amount to the registrar in a single LINK transferAndCall and
+// reads the request hash and upkeep id from the receipt logs.
+func (d *RegistrarV21Deployable) RegisterUpkeep(
+	ctx context.Context,
+	deployer *Deployer,
+	params RegistrationParams,
+) (RegistrationResult, error) {
+	var result RegistrationResult
+
+	if deployer.linkToken == nil {
+		return result, fmt.Errorf("%w: link token required to register upkeeps", ErrConfiguration)
+	}
+
+	registrarABI, err := registrar.AutomationRegistrarMetaData.GetAbi()
+	if err != nil {
+		return result, fmt.Errorf("%w: failed to parse registrar abi: %s", ErrConfiguration, err.Error())
+	}
+
+	// the registrar only accepts calls to register through transferAndCall where the amount and sender match the
+	// token transfer
+	data, err := registrarABI.Pack(
+		"register",
+		params.Name, params.EncryptedEmail, params.UpkeepContract, params.GasLimit, params.AdminAddress,
+		params.TriggerType, params.CheckData, params.TriggerConfig, params.OffchainConfig, params.Amount,
+		deployer.Address,
+	)
+	if err != nil {
+		return result, fmt.Errorf("%w: failed to encode registration: %s", ErrConfiguration, err.Error())
+	}
+
+	opts, err := deployer.BuildTxOpts(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	trx, err := deployer.linkToken.TransferAndCall(opts, common.HexToAddress(d.cCfg.Address), params.Amount, data)
+	if err != nil {
+		return result, fmt.Errorf("%w: registration failed: %s", ErrContractConnection, err.Error())
+	}
+
+	receipt, err := deployer.waitReceipt(ctx, trx)
+	if err != nil || receipt == nil {
+		return result, err
+	}
+
+	return d.registrationResult(receipt)
+}
+
+func (d *RegistrarV21Deployable) registrationResult(receipt *types.Receipt) (RegistrationResult, error) {
+	var result RegistrationResult
+
+	registrarABI, err := registrar.AutomationRegistrarMetaData.GetAbi()
+	if err != nil {
+		return result, fmt.Errorf("%w: failed to parse registrar abi: %s", ErrConfiguration, err.Error())
+	}
+
+	var requested bool
+
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 || log.Address != common.HexToAddress(d.cCfg.Address) {
+			continue
+		}
+
+		switch log.Topics[0] {
+		case registrarABI.Events["RegistrationRequested"].ID:
+			event, err := d.contract.ParseRegistrationRequested(*log)
+			if err != nil {
+				return result, fmt.Errorf("%w: failed to decode RegistrationRequested: %s", ErrContractConnection, err.Error())
+			}
+
+			requested = true
+			result.Hash = event.Hash
+		case registrarABI.Events["RegistrationApproved"].ID:
+			event, err := d.contract.ParseRegistrationApproved(*log)
+			if err != nil {
+				return result, fmt.Errorf("%w: failed to decode RegistrationApproved: %s", ErrContractConnection, err.Error())
+			}
+
+			result.Hash = event.Hash
+			result.Approved = true
+			result.UpkeepID = event.UpkeepId
+		}
+	}
+
+	if !requested && !result.Approved {
+		return result, fmt.Errorf("%w: no registration events in transaction %s", ErrContractConnection, receipt.TxHash)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,235 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	registrar "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/automation_registrar_wrapper2_1"
+)
+
+var ErrRegistrationNotFound = fmt.Errorf("registration request not found")
+
+const (
+	AutoApproveDisabled  uint8 = 0
+	AutoApproveAllowlist uint8 = 1
+	AutoApproveAll       uint8 = 2
+)
+
+// TriggerRegistration is the auto-approve setting and approved count of a single trigger type on the registrar.
+type TriggerRegistration struct {
+	TriggerType     uint8  `json:"triggerType"`
+	AutoApproveType uint8  `json:"autoApproveType"`
+	MaxAllowed      uint32 `json:"maxAllowed"`
+	ApprovedCount   uint32 `json:"approvedCount"`
+}
+
+// PendingRegistration is a registration request that was neither approved nor cancelled.
+type PendingRegistration struct {
+	Hash           common.Hash    `json:"hash"`
+	Name           string         `json:"name"`
+	UpkeepContract common.Address `json:"upkeepContract"`
+	AdminAddress   common.Address `json:"adminAddress"`
+	TriggerType    uint8          `json:"triggerType"`
+	GasLimit       uint32         `json:"gasLimit"`
+	Balance        *big.Int       `json:"balance"`
+	Block          uint64         `json:"block"`
+
+	request *registrar.AutomationRegistrarRegistrationRequested
+}
+
+// TriggerRegistrations returns the auto-approve settings for conditional and log trigger upkeeps.
+func (d *RegistrarV21Deployable) TriggerRegistrations(ctx context.Context) ([]TriggerRegistration, error) {
+	details := make([]TriggerRegistration, 0, 2)
+
+	for _, triggerType := range []uint8{ConditionalTrigger, LogTrigger} {
+		storage, err := d.contract.GetTriggerRegistrationDetails(&bind.CallOpts{Context: ctx}, triggerType)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get trigger registration details: %s", ErrContractConnection, err.Error())
+		}
+
+		details = append(details, TriggerRegistration{
+			TriggerType:     triggerType,
+			AutoApproveType: storage.AutoApproveType,
+			MaxAllowed:      storage.AutoApproveMaxAllowed,
+			ApprovedCount:   storage.ApprovedCount,
+		})
+	}
+
+	return details, nil
+}
+
+// SetTriggerConfig sets the auto-approve type and maximum auto-approved registrations for a trigger type. The
+// environment configuration is updated to match.
+func (d *RegistrarV21Deployable) SetTriggerConfig(
+	ctx context.Context,
+	deployer *Deployer,
+	triggerType, autoApproveType uint8,
+	maxAllowed uint32,
+) error {
+	if err := runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.contract.SetTriggerConfig(opts, triggerType, autoApproveType, maxAllowed)
+	}); err != nil {
+		return err
+	}
+
+	for idx := range d.cCfg.AutoApprovals {
+		if d.cCfg.AutoApprovals[idx].TriggerType == triggerType {
+			d.cCfg.AutoApprovals[idx].AutoApproveType = autoApproveType
+			d.cCfg.AutoApprovals[idx].AutoApproveMaxAllowed = maxAllowed
+
+			return nil
+		}
+	}
+
+	d.cCfg.AutoApprovals = append(d.cCfg.AutoApprovals, config.AutomationRegistrarV21AutoApprovalConfig{
+		TriggerType:           triggerType,
+		AutoApproveType:       autoApproveType,
+		AutoApproveMaxAllowed: maxAllowed,
+	})
+
+	return nil
+}
+
+// PendingRegistrations reads all registration requests from the provided block and returns those that the registrar
+// still holds as pending, sorted by the block they were requested in.
+func (d *RegistrarV21Deployable) PendingRegistrations(
+	ctx context.Context,
+	fromBlock uint64,
+) ([]PendingRegistration, error) {
+	iter, err := d.contract.FilterRegistrationRequested(&bind.FilterOpts{Start: fromBlock, Context: ctx}, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read registration requests: %s", ErrContractConnection, err.Error())
+	}
+
+	defer iter.Close()
+
+	// a request hash is the hash of the registration parameters so the same hash can be requested more than once
+	requests := make(map[common.Hash]*registrar.AutomationRegistrarRegistrationRequested)
+
+	for iter.Next() {
+		requests[iter.Event.Hash] = iter.Event
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("%w: failed to read registration requests: %s", ErrContractConnection, err.Error())
+	}
+
+	pending := make([]PendingRegistration, 0, len(requests))
+
+	for hash, request := range requests {
+		admin, balance, err := d.contract.GetPendingRequest(&bind.CallOpts{Context: ctx}, hash)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get pending request: %s", ErrContractConnection, err.Error())
+		}
+
+		// approved and cancelled requests are deleted from the registrar
+		if admin == (common.Address{}) {
+			continue
+		}
+
+		pending = append(pending, PendingRegistration{
+			Hash:           hash,
+			Name:           request.Name,
+			UpkeepContract: request.UpkeepContract,
+			AdminAddress:   admin,
+			TriggerType:    request.TriggerType,
+			GasLimit:       request.GasLimit,
+			Balance:        balance,
+			Block:          request.Raw.BlockNumber,
+			request:        request,
+		})
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Block < pending[j].Block
+	})
+
+	return pending, nil
+}
+
+// Approve approves a pending registration with the parameters from the original request. The key must be the
+// registrar owner.
+func (d *RegistrarV21Deployable) Approve(
+	ctx context.Context,
+	deployer *Deployer,
+	hash common.Hash,
+	fromBlock uint64,
+) (*big.Int, error) {
+	request, err := d.pendingRequest(ctx, hash, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := deployer.BuildTxOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	trx, err := d.contract.Approve(
+		opts,
+		request.Name, request.UpkeepContract, request.GasLimit, request.AdminAddress, request.TriggerType,
+		request.CheckData, request.TriggerConfig, request.OffchainConfig, hash,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: transaction submit failed: %s", ErrContractConnection, err.Error())
+	}
+
+	receipt, err := deployer.waitReceipt(ctx, trx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+
+	result, err := d.registrationResult(receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.UpkeepID, nil
+}
+
+// Cancel cancels a pending registration and refunds the LINK amount to the admin. The key must be the registration
+// admin or the registrar owner.
+func (d *RegistrarV21Deployable) Cancel(ctx context.Context, deployer *Deployer, hash common.Hash) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.contract.Cancel(opts, hash)
+	})
+}
+
+func (d *RegistrarV21Deployable) pendingRequest(
+	ctx context.Context,
+	hash common.Hash,
+	fromBlock uint64,
+) (*registrar.AutomationRegistrarRegistrationRequested, error) {
+	pending, err := d.PendingRegistrations(ctx, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, registration := range pending {
+		if registration.Hash == hash {
+			return registration.request, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no pending request with hash %s", ErrRegistrationNotFound, hash)
+}
+
+// AutoApproveTypeName returns the display name of a registrar auto-approve type.
+func AutoApproveTypeName(autoApproveType uint8) string {
+	switch autoApproveType {
+	case AutoApproveDisabled:
+		return "disabled"
+	case AutoApproveAllowlist:
+		return "allowlist"
+	case AutoApproveAll:
+		return "all"
+	default:
+		return fmt.Sprintf("unknown (%d)", autoApproveType)
+	}
+}
@@ -0,0 +1,82 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+var ErrUnsupportedVersion = fmt.Errorf("unsupported registry version")
+
+// RegistryDeployable is a registry contract of any supported version that can be deployed, connected to, and
+// configured with the environment participants.
+type RegistryDeployable interface {
+	Connect(context.Context, *Deployer) (common.Address, error)
+	Deploy(context.Context, *Deployer) (common.Address, error)
+	SetOffchainConfig(context.Context, *Deployer, []config.NodeConfig) error
+	Version() string
+}
+
+// RegistryConfigReader is a registry whose latest on-chain config can be read and compared with the config that
+// SetOffchainConfig would set.
+type RegistryConfigReader interface {
+	LatestConfig(context.Context) (RegistryConfig, error)
+	ProposedConfig([]config.NodeConfig) (RegistryConfigValues, error)
+}
+
+// RegistryVersions are the registry versions that can be deployed and configured.
+var RegistryVersions = []string{config.RegistryVersion20, config.RegistryVersion21}
+
+// NewRegistryDeployable returns the registry deployable for the version recorded in the registry configuration.
+func NewRegistryDeployable(
+	link config.LinkTokenContract,
+	linkFeed config.FeedContract,
+	gasFeed config.FeedContract,
+	rCfg *config.AutomationRegistryV21Contract,
+) (RegistryDeployable, error) {
+	switch version := rCfg.ContractVersion(); version {
+	case config.RegistryVersion20:
+		return NewRegistryV20Deployable(link, linkFeed, gasFeed, rCfg), nil
+	case config.RegistryVersion21:
+		return NewRegistryV21Deployable(link, linkFeed, gasFeed, rCfg), nil
+	default:
+		return nil, unsupportedVersion(version)
+	}
+}
+
+// NewDefaultRegistryConfig returns a registry configuration populated with the defaults for the registry version and
+// mode.
+func NewDefaultRegistryConfig(version string, mode uint8) (*config.AutomationRegistryV21Contract, error) {
+	switch version {
+	case config.RegistryVersion20:
+		return NewDefaultRegistryV20Config(mode), nil
+	case config.RegistryVersion21:
+		return NewDefaultRegistryV21Config(mode), nil
+	default:
+		return nil, unsupportedVersion(version)
+	}
+}
+
+// CheckRegistryVersion returns an error if the registry version cannot be deployed or configured.
+func CheckRegistryVersion(version string) error {
+	for _, supported := range RegistryVersions {
+		if version == supported {
+			return nil
+		}
+	}
+
+	return unsupportedVersion(version)
+}
+
+func unsupportedVersion(version string) error {
+	// the pinned chainlink module does not include v2.2 contract wrappers
+	if version == config.RegistryVersion22 {
+		return fmt.Errorf("%w: %s contract wrappers are not available in this build; supported versions are %v",
+			ErrUnsupportedVersion, version, RegistryVersions)
+	}
+
+	return fmt.Errorf("%w: '%s'; supported versions are %v", ErrUnsupportedVersion, version, RegistryVersions)
+}
@@ -0,0 +1,269 @@
+package asset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	ocr2config "github.com/smartcontractkit/libocr/offchainreporting2plus/confighelper"
+	offchain20config "github.com/smartcontractkit/ocr2keepers/pkg/v2/config"
+
+	logic "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/keeper_registry_logic2_0"
+	registry20 "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/keeper_registry_wrapper2_0"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+// RegistryV20Deployable is an OCR2 based v2.0 registry. Upkeep management commands are only available for v2.1.
+type RegistryV20Deployable struct {
+	registry *registry20.KeeperRegistry
+	link     config.LinkTokenContract
+	linkFeed config.FeedContract
+	gasFeed  config.FeedContract
+	rCfg     *config.AutomationRegistryV21Contract
+}
+
+func NewRegistryV20Deployable(
+	link config.LinkTokenContract,
+	linkFeed config.FeedContract,
+	gasFeed config.FeedContract,
+	rCfg *config.AutomationRegistryV21Contract,
+) *RegistryV20Deployable {
+	return &RegistryV20Deployable{
+		link:     link,
+		linkFeed: linkFeed,
+		gasFeed:  gasFeed,
+		rCfg:     rCfg,
+	}
+}
+
+// NewDefaultRegistryV20Config returns a registry configuration with the v2.1 defaults adjusted for the OCR2 network
+// and the single registrar of a v2.0 registry.
+func NewDefaultRegistryV20Config(mode uint8) *config.AutomationRegistryV21Contract {
+	conf := NewDefaultRegistryV21Config(mode)
+
+	conf.Version = config.RegistryVersion20
+	conf.OCRNetwork = config.OCR3NetworkConfig{
+		Version:                                 "v2",
+		DeltaProgress:                           DefaultOCR3DeltaProgress,
+		DeltaResend:                             DefaultOCR3DeltaResend,
+		DeltaRound:                              DefaultOCR3DeltaRound,
+		DeltaGrace:                              DefaultOCR3DeltaGrace,
+		DeltaStage:                              DefaultOCR2DeltaStage,
+		MaxRounds:                               DefaultOCR3MaxRounds,
+		MaxDurationQuery:                        DefaultOCR3MaxDurationQuery,
+		MaxDurationObservation:                  DefaultOCR3MaxDurationObservation,
+		MaxDurationReport:                       DefaultOCR2MaxDurationReport,
+		MaxDurationShouldAcceptFinalizedReport:  DefaultOCR3MaxDurationShouldAcceptFinalizedReport,
+		MaxDurationShouldTransmitAcceptedReport: DefaultOCR3MaxDurationShouldTransmitAcceptedReport,
+	}
+
+	return conf
+}
+
+func (d *RegistryV20Deployable) Version() string {
+	return config.RegistryVersion20
+}
+
+func (d *RegistryV20Deployable) Connect(ctx context.Context, deployer *Deployer) (common.Address, error) {
+	return d.connectToInterface(ctx, common.HexToAddress(d.rCfg.Address), deployer)
+}
+
+func (d *RegistryV20Deployable) Deploy(ctx context.Context, deployer *Deployer) (common.Address, error) {
+	var registryAddr common.Address
+
+	opts, err := deployer.BuildTxOpts(ctx)
+	if err != nil {
+		return registryAddr, fmt.Errorf("%w: deploy failed: %s", ErrContractCreate, err.Error())
+	}
+
+	logicAddr, trx, _, err := logic.DeployKeeperRegistryLogic(
+		opts,
+		deployer.Client,
+		d.rCfg.Mode,
+		common.HexToAddress(d.link.Address),
+		common.HexToAddress(d.linkFeed.Address),
+		common.HexToAddress(d.gasFeed.Address),
+	)
+	if err != nil {
+		return registryAddr, fmt.Errorf("%w: deploy Logic ABI failed: %s", ErrContractCreate, err.Error())
+	}
+
+	if err := deployer.waitDeployment(ctx, trx); err != nil {
+		return registryAddr, err
+	}
+
+	opts, err = deployer.BuildTxOpts(ctx)
+	if err != nil {
+		return registryAddr, fmt.Errorf("%w: deploy failed: %s", ErrContractCreate, err.Error())
+	}
+
+	registryAddr, trx, _, err = registry20.DeployKeeperRegistry(opts, deployer.Client, logicAddr)
+	if err != nil {
+		return registryAddr, fmt.Errorf("%w: deploy Registry ABI failed: %s", ErrContractCreate, err.Error())
+	}
+
+	if err := deployer.waitDeployment(ctx, trx); err != nil {
+		return registryAddr, err
+	}
+
+	d.rCfg.Address = registryAddr.Hex()
+
+	return d.connectToInterface(ctx, registryAddr, deployer)
+}
+
+func (d *RegistryV20Deployable) SetOffchainConfig(
+	ctx context.Context,
+	deployer *Deployer,
+	nodeConfs []config.NodeConfig,
+) error {
+	networkS, oracleIdentities, _, err := makeOracles(nodeConfs)
+	if err != nil {
+		return err
+	}
+
+	offC, err := json.Marshal(offchain20config.OffchainConfig{
+		PerformLockoutWindow: d.rCfg.Offchain.PerformLockoutWindow,
+		TargetProbability:    d.rCfg.Offchain.TargetProbability,
+		TargetInRounds:       d.rCfg.Offchain.TargetInRounds,
+		SamplingJobDuration:  DefaultSamplingJobDuration,
+		MinConfirmations:     d.rCfg.Offchain.MinConfirmations,
+		GasLimitPerReport:    d.rCfg.Offchain.GasLimitPerReport,
+		GasOverheadPerUpkeep: d.rCfg.Offchain.GasOverheadPerUpkeep,
+		MaxUpkeepBatchSize:   d.rCfg.Offchain.MaxUpkeepBatchSize,
+		ReportBlockLag:       DefaultReportBlockLag,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrConfiguration, err.Error())
+	}
+
+	ocr := d.rCfg.OCRNetwork
+	if ocr.MaxRounds > math.MaxUint8 {
+		return fmt.Errorf("%w: OCR2 max rounds must not exceed %d", ErrConfiguration, math.MaxUint8)
+	}
+
+	signerKeys, transmitterAccounts, maxFault, _, offchainConfigVersion, offchainConfig, err :=
+		ocr2config.ContractSetConfigArgsForTests(
+			ocr.DeltaProgress,
+			ocr.DeltaResend,
+			ocr.DeltaRound,
+			ocr.DeltaGrace,
+			ocr.DeltaStage,
+			uint8(ocr.MaxRounds),
+			networkS,
+			oracleIdentities,
+			offC,
+			ocr.MaxDurationQuery,
+			ocr.MaxDurationObservation,
+			ocr.MaxDurationReport,
+			ocr.MaxDurationShouldAcceptFinalizedReport,
+			ocr.MaxDurationShouldTransmitAcceptedReport,
+			ocr.MaxFaultyNodes,
+			nil,
+		)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrConfiguration, err.Error())
+	}
+
+	signers := make([]common.Address, 0, len(signerKeys))
+
+	for _, signer := range signerKeys {
+		if len(signer) != publicKeyLength {
+			return fmt.Errorf("%w: OnChainPublicKey has wrong length for address", ErrConfiguration)
+		}
+
+		signers = append(signers, common.BytesToAddress(signer))
+	}
+
+	transmitters := make([]common.Address, 0, len(transmitterAccounts))
+
+	for _, transmitter := range transmitterAccounts {
+		if !common.IsHexAddress(string(transmitter)) {
+			return fmt.Errorf("%w: TransmitAccount is not a valid Ethereum address", ErrConfiguration)
+		}
+
+		transmitters = append(transmitters, common.HexToAddress(string(transmitter)))
+	}
+
+	onchainConfig, err := encodeV20OnchainConfig(d.rCfg.Onchain)
+	if err != nil {
+		return err
+	}
+
+	opts, err := deployer.BuildTxOpts(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: deploy failed: %s", ErrContractCreate, err.Error())
+	}
+
+	trx, err := d.registry.SetConfig(
+		opts, signers, transmitters, maxFault,
+		onchainConfig, offchainConfigVersion, offchainConfig,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrContractConnection, err.Error())
+	}
+
+	if err := deployer.wait(ctx, trx); err != nil {
+		return fmt.Errorf("%w: %s", ErrContractConnection, err.Error())
+	}
+
+	return nil
+}
+
+func (d *RegistryV20Deployable) connectToInterface(
+	_ context.Context,
+	addr common.Address,
+	deployer *Deployer,
+) (common.Address, error) {
+	contract, err := registry20.NewKeeperRegistry(addr, deployer.Client)
+	if err != nil {
+		return addr, fmt.Errorf("%w: failed to connect to contract at (%s): %s", ErrContractConnection, addr, err.Error())
+	}
+
+	d.registry = contract
+
+	return addr, nil
+}
+
+// encodeV20OnchainConfig abi encodes the on-chain config as the v2.0 registry expects it in setConfig. The first
+// configured registrar is used as v2.0 registries only support one.
+func encodeV20OnchainConfig(onchain config.AutomationV21OnchainConfig) ([]byte, error) {
+	registryABI, err := registry20.KeeperRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse registry abi: %s", ErrConfiguration, err.Error())
+	}
+
+	// the on-chain config struct is the second output of getState
+	configType := registryABI.Methods["getState"].Outputs[1].Type
+
+	var registrar common.Address
+	if len(onchain.Registrars) > 0 {
+		registrar = common.HexToAddress(onchain.Registrars[0])
+	}
+
+	encoded, err := abi.Arguments{{Type: configType}}.Pack(registry20.OnchainConfig{
+		PaymentPremiumPPB:    onchain.PaymentPremiumPPB,
+		FlatFeeMicroLink:     onchain.FlatFeeMicroLink,
+		CheckGasLimit:        onchain.CheckGasLimit,
+		StalenessSeconds:     big.NewInt(onchain.StalenessSeconds),
+		GasCeilingMultiplier: onchain.GasCeilingMultiplier,
+		MinUpkeepSpend:       big.NewInt(onchain.MinUpkeepSpend),
+		MaxPerformGas:        onchain.MaxPerformGas,
+		MaxCheckDataSize:     onchain.MaxCheckDataSize,
+		MaxPerformDataSize:   onchain.MaxPerformDataSize,
+		FallbackGasPrice:     big.NewInt(onchain.FallbackGasPrice),
+		FallbackLinkPrice:    big.NewInt(onchain.FallbackLinkPrice),
+		Transcoder:           common.HexToAddress(onchain.Transcoder),
+		Registrar:            registrar,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode on-chain config: %s", ErrConfiguration, err.Error())
+	}
+
+	return encoded, nil
+}
@@ -0,0 +1,231 @@
+package asset
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ocr2config "github.com/smartcontractkit/libocr/offchainreporting2plus/confighelper"
+	ocr2types "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	offchain20config "github.com/smartcontractkit/ocr2keepers/pkg/v2/config"
+
+	registry20 "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/keeper_registry_wrapper2_0"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+// LatestConfig reads the latest config details and the matching ConfigSet event and decodes the OCR2 offchain config
+// and the automation plugin config it contains.
+func (d *RegistryV20Deployable) LatestConfig(ctx context.Context) (RegistryConfig, error) {
+	var result RegistryConfig
+
+	opts := &bind.CallOpts{Context: ctx}
+
+	details, err := d.registry.LatestConfigDetails(opts)
+	if err != nil {
+		return result, fmt.Errorf("%w: failed to get latest config details: %s", ErrContractConnection, err.Error())
+	}
+
+	if details.BlockNumber == 0 {
+		return result, nil
+	}
+
+	result.ConfigDigest = details.ConfigDigest
+	result.ConfigCount = details.ConfigCount
+	result.BlockNumber = details.BlockNumber
+
+	event, err := d.configSetEvent(ctx, uint64(details.BlockNumber), details.ConfigDigest)
+	if err != nil {
+		return result, err
+	}
+
+	state, err := d.registry.GetState(opts)
+	if err != nil {
+		return result, fmt.Errorf("%w: failed to get registry state: %s", ErrContractConnection, err.Error())
+	}
+
+	public, err := decodeOCR2Config(event)
+	if err != nil {
+		return result, err
+	}
+
+	var plugin offchain20config.OffchainConfig
+
+	if err := json.Unmarshal(public.ReportingPluginConfig, &plugin); err != nil {
+		return result, fmt.Errorf("%w: failed to decode plugin config: %s", ErrConfiguration, err.Error())
+	}
+
+	result.TxHash = event.Raw.TxHash
+	result.OffchainConfigVersion = event.OffchainConfigVersion
+	result.PluginConfig = public.ReportingPluginConfig
+	result.Oracles = make([]RegistryOracle, len(event.Signers))
+
+	for idx := range event.Signers {
+		result.Oracles[idx] = RegistryOracle{
+			Signer:      event.Signers[idx],
+			Transmitter: event.Transmitters[idx],
+		}
+
+		if idx < len(public.OracleIdentities) {
+			result.Oracles[idx].PeerID = public.OracleIdentities[idx].PeerID
+			result.Oracles[idx].OffchainPublicKey = hex.EncodeToString(public.OracleIdentities[idx].OffchainPublicKey[:])
+		}
+	}
+
+	result.Values = RegistryConfigValues{
+		Signers:      event.Signers,
+		Transmitters: event.Transmitters,
+		Onchain:      onchainConfigFromV20(state.Config),
+		Offchain: config.AutomationV21OffchainConfig{
+			PerformLockoutWindow: plugin.PerformLockoutWindow,
+			MinConfirmations:     plugin.MinConfirmations,
+			TargetProbability:    plugin.TargetProbability,
+			TargetInRounds:       plugin.TargetInRounds,
+			GasLimitPerReport:    plugin.GasLimitPerReport,
+			GasOverheadPerUpkeep: plugin.GasOverheadPerUpkeep,
+			MaxUpkeepBatchSize:   plugin.MaxUpkeepBatchSize,
+		},
+		OCRNetwork: config.OCR3NetworkConfig{
+			Version:                                 "v2",
+			DeltaProgress:                           public.DeltaProgress,
+			DeltaResend:                             public.DeltaResend,
+			DeltaRound:                              public.DeltaRound,
+			DeltaGrace:                              public.DeltaGrace,
+			DeltaStage:                              public.DeltaStage,
+			MaxRounds:                               uint64(public.RMax),
+			MaxDurationQuery:                        public.MaxDurationQuery,
+			MaxDurationObservation:                  public.MaxDurationObservation,
+			MaxDurationReport:                       public.MaxDurationReport,
+			MaxDurationShouldAcceptFinalizedReport:  public.MaxDurationShouldAcceptFinalizedReport,
+			MaxDurationShouldTransmitAcceptedReport: public.MaxDurationShouldTransmitAcceptedReport,
+			MaxFaultyNodes:                          public.F,
+		},
+	}
+
+	return result, nil
+}
+
+// ProposedConfig returns the configuration that SetOffchainConfig would set for the provided nodes in the same form
+// as LatestConfig such that the two can be compared. Fields that a v2.0 registry does not support are left empty.
+func (d *RegistryV20Deployable) ProposedConfig(nodeConfs []config.NodeConfig) (RegistryConfigValues, error) {
+	onchain := d.rCfg.Onchain
+	onchain.MaxRevertDataSize = 0
+	onchain.UpkeepPrivilegeManager = ""
+	onchain.Registrars = nil
+
+	// only the first registrar is set on a v2.0 registry
+	if len(d.rCfg.Onchain.Registrars) > 0 {
+		onchain.Registrars = d.rCfg.Onchain.Registrars[:1]
+	}
+
+	ocr := d.rCfg.OCRNetwork
+
+	values := RegistryConfigValues{
+		Onchain:  onchain,
+		Offchain: d.rCfg.Offchain,
+		OCRNetwork: config.OCR3NetworkConfig{
+			Version:                                 "v2",
+			DeltaProgress:                           ocr.DeltaProgress,
+			DeltaResend:                             ocr.DeltaResend,
+			DeltaRound:                              ocr.DeltaRound,
+			DeltaGrace:                              ocr.DeltaGrace,
+			DeltaStage:                              ocr.DeltaStage,
+			MaxRounds:                               ocr.MaxRounds,
+			MaxDurationQuery:                        ocr.MaxDurationQuery,
+			MaxDurationObservation:                  ocr.MaxDurationObservation,
+			MaxDurationReport:                       ocr.MaxDurationReport,
+			MaxDurationShouldAcceptFinalizedReport:  ocr.MaxDurationShouldAcceptFinalizedReport,
+			MaxDurationShouldTransmitAcceptedReport: ocr.MaxDurationShouldTransmitAcceptedReport,
+			MaxFaultyNodes:                          ocr.MaxFaultyNodes,
+		},
+	}
+
+	signers, transmitters, err := oracleAddresses(nodeConfs)
+	if err != nil {
+		return values, err
+	}
+
+	values.Signers = signers
+	values.Transmitters = transmitters
+
+	return values, nil
+}
+
+func (d *RegistryV20Deployable) configSetEvent(
+	ctx context.Context,
+	block uint64,
+	digest [32]byte,
+) (*registry20.KeeperRegistryConfigSet, error) {
+	iter, err := d.registry.FilterConfigSet(&bind.FilterOpts{Start: block, End: &block, Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read ConfigSet logs: %s", ErrContractConnection, err.Error())
+	}
+
+	defer iter.Close()
+
+	for iter.Next() {
+		if iter.Event.ConfigDigest == digest {
+			return iter.Event, nil
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("%w: failed to read ConfigSet logs: %s", ErrContractConnection, err.Error())
+	}
+
+	return nil, fmt.Errorf("%w: no ConfigSet log for digest %x in block %d", ErrContractConnection, digest, block)
+}
+
+func decodeOCR2Config(event *registry20.KeeperRegistryConfigSet) (ocr2config.PublicConfig, error) {
+	signers := make([]ocr2types.OnchainPublicKey, len(event.Signers))
+	for idx, signer := range event.Signers {
+		signers[idx] = signer.Bytes()
+	}
+
+	transmitters := make([]ocr2types.Account, len(event.Transmitters))
+	for idx, transmitter := range event.Transmitters {
+		transmitters[idx] = ocr2types.Account(transmitter.Hex())
+	}
+
+	public, err := ocr2config.PublicConfigFromContractConfig(true, ocr2types.ContractConfig{
+		ConfigDigest:          event.ConfigDigest,
+		ConfigCount:           event.ConfigCount,
+		Signers:               signers,
+		Transmitters:          transmitters,
+		F:                     event.F,
+		OnchainConfig:         event.OnchainConfig,
+		OffchainConfigVersion: event.OffchainConfigVersion,
+		OffchainConfig:        event.OffchainConfig,
+	})
+	if err != nil {
+		return public, fmt.Errorf("%w: failed to decode OCR2 offchain config: %s", ErrConfiguration, err.Error())
+	}
+
+	return public, nil
+}
+
+func onchainConfigFromV20(onchain registry20.OnchainConfig) config.AutomationV21OnchainConfig {
+	var registrars []string
+
+	if registrar := addressOrEmpty(onchain.Registrar); registrar != "0x" {
+		registrars = []string{registrar}
+	}
+
+	return config.AutomationV21OnchainConfig{
+		PaymentPremiumPPB:    onchain.PaymentPremiumPPB,
+		FlatFeeMicroLink:     onchain.FlatFeeMicroLink,
+		CheckGasLimit:        onchain.CheckGasLimit,
+		StalenessSeconds:     onchain.StalenessSeconds.Int64(),
+		GasCeilingMultiplier: onchain.GasCeilingMultiplier,
+		MinUpkeepSpend:       onchain.MinUpkeepSpend.Int64(),
+		MaxPerformGas:        onchain.MaxPerformGas,
+		MaxCheckDataSize:     onchain.MaxCheckDataSize,
+		MaxPerformDataSize:   onchain.MaxPerformDataSize,
+		FallbackGasPrice:     onchain.FallbackGasPrice.Int64(),
+		FallbackLinkPrice:    onchain.FallbackLinkPrice.Int64(),
+		Transcoder:           addressOrEmpty(onchain.Transcoder),
+		Registrars:           registrars,
+	}
+}
@@ -56,6 +56,60 @@ func NewRegistryV21Deployable(
 	}
 }
 
+// NewDefaultRegistryV21Config returns a registry configuration populated with the default on-chain, off-chain, and
+// OCR network values for the provided registry mode.
+func NewDefaultRegistryV21Config(mode uint8) *config.AutomationRegistryV21Contract {
+	return &config.AutomationRegistryV21Contract{
+		Type:    config.AutomationRegistryContractType,
+		Version: config.RegistryVersion21,
+		Mode:    mode,
+		Offchain: config.AutomationV21OffchainConfig{
+			PerformLockoutWindow: DefaultPerformLockoutWindow,
+			MinConfirmations:     DefaultMinConfirmations,
+			TargetProbability:    DefaultTargetProbability,
+			TargetInRounds:       DefaultTargetInRounds,
+			GasLimitPerReport:    DefaultGasLimitPerReport,
+			GasOverheadPerUpkeep: DefaultGasOverheadPerUpkeep,
+			MaxUpkeepBatchSize:   DefaultMaxUpkeepBatchSize,
+		},
+		Onchain: config.AutomationV21OnchainConfig{
+			PaymentPremiumPPB:      DefaultPaymentPremiumPPB,
+			FlatFeeMicroLink:       DefaultFlatFeeMicroLink,
+			CheckGasLimit:          DefaultCheckGasLimit,
+			StalenessSeconds:       DefaultStalenessSeconds,
+			GasCeilingMultiplier:   DefaultGasCeilingMultiplier,
+			MinUpkeepSpend:         DefaultMinUpkeepSpend,
+			MaxPerformGas:          DefaultMaxPerformGas,
+			MaxCheckDataSize:       DefaultMaxCheckDataSize,
+			MaxPerformDataSize:     DefaultMaxPerformDataSize,
+			MaxRevertDataSize:      DefaultMaxRevertDataSize,
+			FallbackGasPrice:       DefaultFallbackGasPrice,
+			FallbackLinkPrice:      DefaultFallbackLinkPrice,
+			Transcoder:             "0x", // set by deploy-transcoder
+			UpkeepPrivilegeManager: "0x", // not supported
+		},
+		OCRNetwork: config.OCR3NetworkConfig{
+			Version:                                 "v3",
+			DeltaProgress:                           DefaultOCR3DeltaProgress,
+			DeltaResend:                             DefaultOCR3DeltaResend,
+			DeltaInitial:                            DefaultOCR3DeltaInitial,
+			DeltaRound:                              DefaultOCR3DeltaRound,
+			DeltaGrace:                              DefaultOCR3DeltaGrace,
+			DeltaCertifiedCommitRequest:             DefaultOCR3DeltaCertifiedCommitRequest,
+			DeltaStage:                              DefaultOCR3DeltaStage,
+			MaxRounds:                               DefaultOCR3MaxRounds,
+			MaxDurationQuery:                        DefaultOCR3MaxDurationQuery,
+			MaxDurationObservation:                  DefaultOCR3MaxDurationObservation,
+			MaxDurationShouldAcceptFinalizedReport:  DefaultOCR3MaxDurationShouldAcceptFinalizedReport,
+			MaxDurationShouldTransmitAcceptedReport: DefaultOCR3MaxDurationShouldTransmitAcceptedReport,
+		},
+	}
+}
+
+func (d *RegistryV21Deployable) Version() string {
+	return config.RegistryVersion21
+}
+
 func (d *RegistryV21Deployable) Connect(ctx context.Context, deployer *Deployer) (common.Address, error) {
 	return d.connectToInterface(ctx, common.HexToAddress(d.rCfg.Address), deployer)
 }
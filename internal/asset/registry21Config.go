@@ -0,0 +1,312 @@
+package asset
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3confighelper"
+	ocr2types "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+	offchain21config "github.com/smartcontractkit/ocr2keepers/pkg/v3/config"
+
+	iregistry "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/i_keeper_registry_master_wrapper_2_1"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+// RegistryConfig is the configuration set on the registry by the latest setConfig transaction. A registry that was
+// never configured has a zero block number and empty values.
+type RegistryConfig struct {
+	ConfigDigest          common.Hash          `json:"configDigest"`
+	ConfigCount           uint32               `json:"configCount"`
+	BlockNumber           uint32               `json:"blockNumber"`
+	TxHash                common.Hash          `json:"txHash"`
+	OffchainConfigVersion uint64               `json:"offchainConfigVersion"`
+	Oracles               []RegistryOracle     `json:"oracles"`
+	PluginConfig          json.RawMessage      `json:"pluginConfig"`
+	Values                RegistryConfigValues `json:"values"`
+}
+
+// RegistryOracle is a single oracle from the OCR3 config. The signer and transmitter are from the ConfigSet event and
+// the peer id and offchain key are from the decoded offchain config.
+type RegistryOracle struct {
+	Signer            common.Address `json:"signer"`
+	Transmitter       common.Address `json:"transmitter"`
+	PeerID            string         `json:"peerId"`
+	OffchainPublicKey string         `json:"offchainPublicKey"`
+}
+
+// OracleCheck is the result of matching an on-chain oracle against the environment participants by transmitter.
+type OracleCheck struct {
+	RegistryOracle
+	Participant      string `json:"participant"`
+	SignerMatch      bool   `json:"signerMatch"`
+	TransmitterMatch bool   `json:"transmitterMatch"`
+}
+
+// RegistryConfigValues are the comparable parts of a registry configuration.
+type RegistryConfigValues struct {
+	Signers      []common.Address
+	Transmitters []common.Address
+	Onchain      config.AutomationV21OnchainConfig
+	Offchain     config.AutomationV21OffchainConfig
+	OCRNetwork   config.OCR3NetworkConfig
+}
+
+// LatestConfig reads the latest config details and the matching ConfigSet event and decodes the OCR3 offchain config
+// and the automation plugin config it contains.
+func (d *RegistryV21Deployable) LatestConfig(ctx context.Context) (RegistryConfig, error) {
+	var result RegistryConfig
+
+	opts := &bind.CallOpts{Context: ctx}
+
+	details, err := d.registry.LatestConfigDetails(opts)
+	if err != nil {
+		return result, fmt.Errorf("%w: failed to get latest config details: %s", ErrContractConnection, err.Error())
+	}
+
+	if details.BlockNumber == 0 {
+		return result, nil
+	}
+
+	result.ConfigDigest = details.ConfigDigest
+	result.ConfigCount = details.ConfigCount
+	result.BlockNumber = details.BlockNumber
+
+	event, err := d.configSetEvent(ctx, uint64(details.BlockNumber), details.ConfigDigest)
+	if err != nil {
+		return result, err
+	}
+
+	state, err := d.registry.GetState(opts)
+	if err != nil {
+		return result, fmt.Errorf("%w: failed to get registry state: %s", ErrContractConnection, err.Error())
+	}
+
+	public, err := decodeOCR3Config(event)
+	if err != nil {
+		return result, err
+	}
+
+	var plugin offchain21config.OffchainConfig
+
+	if err := json.Unmarshal(public.ReportingPluginConfig, &plugin); err != nil {
+		return result, fmt.Errorf("%w: failed to decode plugin config: %s", ErrConfiguration, err.Error())
+	}
+
+	result.TxHash = event.Raw.TxHash
+	result.OffchainConfigVersion = event.OffchainConfigVersion
+	result.PluginConfig = public.ReportingPluginConfig
+	result.Oracles = make([]RegistryOracle, len(event.Signers))
+
+	for idx := range event.Signers {
+		result.Oracles[idx] = RegistryOracle{
+			Signer:      event.Signers[idx],
+			Transmitter: event.Transmitters[idx],
+		}
+
+		if idx < len(public.OracleIdentities) {
+			result.Oracles[idx].PeerID = public.OracleIdentities[idx].PeerID
+			result.Oracles[idx].OffchainPublicKey = hex.EncodeToString(public.OracleIdentities[idx].OffchainPublicKey[:])
+		}
+	}
+
+	result.Values = RegistryConfigValues{
+		Signers:      event.Signers,
+		Transmitters: event.Transmitters,
+		Onchain:      onchainConfigFrom(state.Config),
+		Offchain: config.AutomationV21OffchainConfig{
+			PerformLockoutWindow: plugin.PerformLockoutWindow,
+			MinConfirmations:     plugin.MinConfirmations,
+			TargetProbability:    plugin.TargetProbability,
+			TargetInRounds:       plugin.TargetInRounds,
+			GasLimitPerReport:    plugin.GasLimitPerReport,
+			GasOverheadPerUpkeep: plugin.GasOverheadPerUpkeep,
+			MaxUpkeepBatchSize:   plugin.MaxUpkeepBatchSize,
+		},
+		OCRNetwork: config.OCR3NetworkConfig{
+			Version:                                 "v3",
+			DeltaProgress:                           public.DeltaProgress,
+			DeltaResend:                             public.DeltaResend,
+			DeltaInitial:                            public.DeltaInitial,
+			DeltaRound:                              public.DeltaRound,
+			DeltaGrace:                              public.DeltaGrace,
+			DeltaCertifiedCommitRequest:             public.DeltaCertifiedCommitRequest,
+			DeltaStage:                              public.DeltaStage,
+			MaxRounds:                               public.RMax,
+			MaxDurationQuery:                        public.MaxDurationQuery,
+			MaxDurationObservation:                  public.MaxDurationObservation,
+			MaxDurationShouldAcceptFinalizedReport:  public.MaxDurationShouldAcceptAttestedReport,
+			MaxDurationShouldTransmitAcceptedReport: public.MaxDurationShouldTransmitAcceptedReport,
+			MaxFaultyNodes:                          public.F,
+		},
+	}
+
+	return result, nil
+}
+
+// ProposedConfig returns the configuration that SetOffchainConfig would set for the provided nodes in the same form
+// as LatestConfig such that the two can be compared.
+func (d *RegistryV21Deployable) ProposedConfig(nodeConfs []config.NodeConfig) (RegistryConfigValues, error) {
+	values := RegistryConfigValues{
+		Onchain:    onchainConfigFrom(makeOnchainConfig(d.rCfg.Onchain)),
+		Offchain:   d.rCfg.Offchain,
+		OCRNetwork: d.rCfg.OCRNetwork,
+	}
+
+	signers, transmitters, err := oracleAddresses(nodeConfs)
+	if err != nil {
+		return values, err
+	}
+
+	values.Signers = signers
+	values.Transmitters = transmitters
+
+	return values, nil
+}
+
+// oracleAddresses returns the signer and transmitter addresses of the nodes in the order they are set on-chain.
+func oracleAddresses(nodeConfs []config.NodeConfig) ([]common.Address, []common.Address, error) {
+	signers := make([]common.Address, 0, len(nodeConfs))
+	transmitters := make([]common.Address, 0, len(nodeConfs))
+
+	for _, node := range nodeConfs {
+		signer, err := hex.DecodeString(strings.TrimPrefix(node.OnchainPublicKey, "ocr2on_evm_"))
+		if err != nil || len(signer) != publicKeyLength {
+			return nil, nil, fmt.Errorf("%w: invalid onchain public key '%s'", ErrConfiguration, node.OnchainPublicKey)
+		}
+
+		signers = append(signers, common.BytesToAddress(signer))
+		transmitters = append(transmitters, common.HexToAddress(node.Address))
+	}
+
+	return signers, transmitters, nil
+}
+
+// CheckOracles matches each on-chain oracle to the participant with the same transmitter address and reports whether
+// the signer matches the participant onchain public key. Participants that are not part of the on-chain config are
+// returned separately.
+func CheckOracles(oracles []RegistryOracle, nodeConfs []config.NodeConfig) ([]OracleCheck, []string) {
+	checks := make([]OracleCheck, len(oracles))
+	matched := make(map[int]bool)
+
+	for idx, oracle := range oracles {
+		checks[idx] = OracleCheck{RegistryOracle: oracle}
+
+		for nodeIdx, node := range nodeConfs {
+			if !common.IsHexAddress(node.Address) || common.HexToAddress(node.Address) != oracle.Transmitter {
+				continue
+			}
+
+			signer, err := hex.DecodeString(strings.TrimPrefix(node.OnchainPublicKey, "ocr2on_evm_"))
+
+			checks[idx].Participant = node.Name
+			checks[idx].TransmitterMatch = true
+			checks[idx].SignerMatch = err == nil && common.BytesToAddress(signer) == oracle.Signer
+			matched[nodeIdx] = true
+
+			break
+		}
+	}
+
+	missing := make([]string, 0)
+
+	for idx, node := range nodeConfs {
+		if !matched[idx] {
+			missing = append(missing, node.Name)
+		}
+	}
+
+	return checks, missing
+}
+
+func (d *RegistryV21Deployable) configSetEvent(
+	ctx context.Context,
+	block uint64,
+	digest [32]byte,
+) (*iregistry.IKeeperRegistryMasterConfigSet, error) {
+	iter, err := d.registry.FilterConfigSet(&bind.FilterOpts{Start: block, End: &block, Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read ConfigSet logs: %s", ErrContractConnection, err.Error())
+	}
+
+	defer iter.Close()
+
+	for iter.Next() {
+		if iter.Event.ConfigDigest == digest {
+			return iter.Event, nil
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("%w: failed to read ConfigSet logs: %s", ErrContractConnection, err.Error())
+	}
+
+	return nil, fmt.Errorf("%w: no ConfigSet log for digest %x in block %d", ErrContractConnection, digest, block)
+}
+
+func decodeOCR3Config(event *iregistry.IKeeperRegistryMasterConfigSet) (ocr3confighelper.PublicConfig, error) {
+	signers := make([]ocr2types.OnchainPublicKey, len(event.Signers))
+	for idx, signer := range event.Signers {
+		signers[idx] = signer.Bytes()
+	}
+
+	transmitters := make([]ocr2types.Account, len(event.Transmitters))
+	for idx, transmitter := range event.Transmitters {
+		transmitters[idx] = ocr2types.Account(transmitter.Hex())
+	}
+
+	public, err := ocr3confighelper.PublicConfigFromContractConfig(true, ocr2types.ContractConfig{
+		ConfigDigest:          event.ConfigDigest,
+		ConfigCount:           event.ConfigCount,
+		Signers:               signers,
+		Transmitters:          transmitters,
+		F:                     event.F,
+		OnchainConfig:         event.OnchainConfig,
+		OffchainConfigVersion: event.OffchainConfigVersion,
+		OffchainConfig:        event.OffchainConfig,
+	})
+	if err != nil {
+		return public, fmt.Errorf("%w: failed to decode OCR3 offchain config: %s", ErrConfiguration, err.Error())
+	}
+
+	return public, nil
+}
+
+func onchainConfigFrom(onchain iregistry.KeeperRegistryBase21OnchainConfig) config.AutomationV21OnchainConfig {
+	registrars := make([]string, len(onchain.Registrars))
+	for idx := range registrars {
+		registrars[idx] = onchain.Registrars[idx].Hex()
+	}
+
+	return config.AutomationV21OnchainConfig{
+		PaymentPremiumPPB:      onchain.PaymentPremiumPPB,
+		FlatFeeMicroLink:       onchain.FlatFeeMicroLink,
+		CheckGasLimit:          onchain.CheckGasLimit,
+		StalenessSeconds:       onchain.StalenessSeconds.Int64(),
+		GasCeilingMultiplier:   onchain.GasCeilingMultiplier,
+		MinUpkeepSpend:         onchain.MinUpkeepSpend.Int64(),
+		MaxPerformGas:          onchain.MaxPerformGas,
+		MaxCheckDataSize:       onchain.MaxCheckDataSize,
+		MaxPerformDataSize:     onchain.MaxPerformDataSize,
+		MaxRevertDataSize:      onchain.MaxRevertDataSize,
+		FallbackGasPrice:       onchain.FallbackGasPrice.Int64(),
+		FallbackLinkPrice:      onchain.FallbackLinkPrice.Int64(),
+		Transcoder:             addressOrEmpty(onchain.Transcoder),
+		Registrars:             registrars,
+		UpkeepPrivilegeManager: addressOrEmpty(onchain.UpkeepPrivilegeManager),
+	}
+}
+
+// addressOrEmpty returns the hex address or "0x" for the zero address to match the default configuration.
+func addressOrEmpty(addr common.Address) string {
+	if addr == (common.Address{}) {
+		return "0x"
+	}
+
+	return addr.Hex()
+}
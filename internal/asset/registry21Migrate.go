@@ -0,0 +1,156 @@
+package asset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	MigrationPermissionNone          uint8 = 0
+	MigrationPermissionOutgoing      uint8 = 1
+	MigrationPermissionIncoming      uint8 = 2
+	MigrationPermissionBidirectional uint8 = 3
+
+	// DefaultMigrationBatchSize is the number of upkeeps moved in a single migrateUpkeeps transaction
+	DefaultMigrationBatchSize = 20
+)
+
+var ErrMigration = fmt.Errorf("upkeep migration failure")
+
+// MigrationCheck is the comparison of an upkeep on the source registry before migration with the same upkeep on the
+// destination registry after migration.
+type MigrationCheck struct {
+	ID                 *big.Int `json:"id"`
+	SourceBalance      *big.Int `json:"sourceBalance"`
+	DestinationBalance *big.Int `json:"destinationBalance"`
+	Mismatches         []string `json:"mismatches"`
+}
+
+// Address returns the address of the connected registry.
+func (d *RegistryV21Deployable) Address() common.Address {
+	return common.HexToAddress(d.rCfg.Address)
+}
+
+// AllowMigration sets the peer migration permission on the registry such that upkeeps can move in the provided
+// direction. An existing permission in the other direction is kept by making the permission bidirectional. No
+// transaction is sent if the direction is already permitted.
+func (d *RegistryV21Deployable) AllowMigration(
+	ctx context.Context,
+	deployer *Deployer,
+	peer common.Address,
+	direction uint8,
+) error {
+	current, err := d.registry.GetPeerRegistryMigrationPermission(&bind.CallOpts{Context: ctx}, peer)
+	if err != nil {
+		return fmt.Errorf("%w: failed to get migration permission: %s", ErrContractConnection, err.Error())
+	}
+
+	if current == direction || current == MigrationPermissionBidirectional {
+		return nil
+	}
+
+	permission := direction
+	if current != MigrationPermissionNone {
+		permission = MigrationPermissionBidirectional
+	}
+
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.SetPeerRegistryMigrationPermission(opts, peer, permission)
+	})
+}
+
+// CheckMigrationSource returns an error if upkeeps cannot be migrated out of the registry. A v2.1 registry requires a
+// transcoder in the on-chain config to migrate upkeeps.
+func (d *RegistryV21Deployable) CheckMigrationSource(ctx context.Context) error {
+	state, err := d.registry.GetState(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("%w: failed to get registry state: %s", ErrContractConnection, err.Error())
+	}
+
+	if state.Config.Transcoder == (common.Address{}) {
+		return fmt.Errorf("%w: source registry has no transcoder; run deploy-transcoder and set-config",
+			ErrMigration)
+	}
+
+	return nil
+}
+
+// MigrateUpkeeps moves the upkeeps to the destination registry in batches. The deployer must be the admin of every
+// upkeep and the destination must be a permitted migration target.
+func (d *RegistryV21Deployable) MigrateUpkeeps(
+	ctx context.Context,
+	deployer *Deployer,
+	destination common.Address,
+	upkeepIDs []*big.Int,
+	batchSize int,
+) error {
+	if batchSize <= 0 {
+		batchSize = DefaultMigrationBatchSize
+	}
+
+	for start := 0; start < len(upkeepIDs); start += batchSize {
+		batch := upkeepIDs[start:min(start+batchSize, len(upkeepIDs))]
+
+		if err := runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return d.registry.MigrateUpkeeps(opts, batch, destination)
+		}); err != nil {
+			return fmt.Errorf("%w: batch starting at upkeep %s: %s", ErrMigration, batch[0], err.Error())
+		}
+	}
+
+	return nil
+}
+
+// CompareMigratedUpkeeps checks that each upkeep read from the source registry before migration exists on the
+// destination with the same balance, admin, target, and configuration.
+func CompareMigratedUpkeeps(before, after []UpkeepDetail) []MigrationCheck {
+	migrated := make(map[string]UpkeepDetail, len(after))
+
+	for _, detail := range after {
+		migrated[detail.ID.String()] = detail
+	}
+
+	checks := make([]MigrationCheck, 0, len(before))
+
+	for _, source := range before {
+		check := MigrationCheck{ID: source.ID, SourceBalance: source.Balance, Mismatches: []string{}}
+
+		destination, ok := migrated[source.ID.String()]
+		if !ok || destination.Target == (common.Address{}) {
+			check.Mismatches = append(check.Mismatches, "missing")
+			checks = append(checks, check)
+
+			continue
+		}
+
+		check.DestinationBalance = destination.Balance
+
+		for _, field := range []struct {
+			name  string
+			equal bool
+		}{
+			{"balance", source.Balance.Cmp(destination.Balance) == 0},
+			{"admin", source.Admin == destination.Admin},
+			{"target", source.Target == destination.Target},
+			{"trigger type", source.TriggerType == destination.TriggerType},
+			{"trigger config", bytes.Equal(source.TriggerConfig, destination.TriggerConfig)},
+			{"gas limit", source.GasLimit == destination.GasLimit},
+			{"check data", bytes.Equal(source.CheckData, destination.CheckData)},
+			{"offchain config", bytes.Equal(source.OffchainConfig, destination.OffchainConfig)},
+		} {
+			if !field.equal {
+				check.Mismatches = append(check.Mismatches, field.name)
+			}
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
@@ -0,0 +1,125 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ignorePayee leaves the payee of a transmitter unchanged in setPayees.
+//
+//nolint:gochecknoglobals
+var ignorePayee = common.HexToAddress("0xFFfFfFffFFfffFFfFFfFFFFFffFFFffffFfFFFfF")
+
+var ErrPayee = fmt.Errorf("transmitter payee failure")
+
+// TransmitterPayment is the payee and the LINK accrued by a transmitter on the registry.
+type TransmitterPayment struct {
+	Transmitter   common.Address `json:"transmitter"`
+	Payee         common.Address `json:"payee"`
+	Active        bool           `json:"active"`
+	Balance       *big.Int       `json:"balance"`
+	LastCollected *big.Int       `json:"lastCollected"`
+}
+
+// Payments reads the payee and balance of each current transmitter on the registry.
+func (d *RegistryV21Deployable) Payments(ctx context.Context) ([]TransmitterPayment, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	state, err := d.registry.GetState(opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get registry state: %s", ErrContractConnection, err.Error())
+	}
+
+	payments := make([]TransmitterPayment, 0, len(state.Transmitters))
+
+	for _, transmitter := range state.Transmitters {
+		info, err := d.registry.GetTransmitterInfo(opts, transmitter)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get transmitter info: %s", ErrContractConnection, err.Error())
+		}
+
+		payments = append(payments, TransmitterPayment{
+			Transmitter:   transmitter,
+			Payee:         info.Payee,
+			Active:        info.Active,
+			Balance:       info.Balance,
+			LastCollected: info.LastCollected,
+		})
+	}
+
+	return payments, nil
+}
+
+// SetPayees sets the payee for each transmitter in the provided map. Transmitters that are not in the map keep their
+// current payee. A payee that is already set can only be changed by the current payee through a payeeship transfer.
+func (d *RegistryV21Deployable) SetPayees(
+	ctx context.Context,
+	deployer *Deployer,
+	payees map[common.Address]common.Address,
+) error {
+	payments, err := d.Payments(ctx)
+	if err != nil {
+		return err
+	}
+
+	ordered := make([]common.Address, len(payments))
+	matched := 0
+
+	for idx, payment := range payments {
+		payee, ok := payees[payment.Transmitter]
+		if !ok {
+			ordered[idx] = ignorePayee
+
+			continue
+		}
+
+		if payment.Payee != (common.Address{}) && payment.Payee != payee {
+			return fmt.Errorf("%w: transmitter %s already has payee %s", ErrPayee, payment.Transmitter, payment.Payee)
+		}
+
+		ordered[idx] = payee
+		matched++
+	}
+
+	if matched != len(payees) {
+		return fmt.Errorf("%w: %d of %d transmitters are not in the registry config", ErrPayee, len(payees)-matched,
+			len(payees))
+	}
+
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.SetPayees(opts, ordered)
+	})
+}
+
+// WithdrawPayment sends the LINK accrued by a transmitter to the provided address. The deployer must be the payee of
+// the transmitter.
+func (d *RegistryV21Deployable) WithdrawPayment(
+	ctx context.Context,
+	deployer *Deployer,
+	transmitter, to common.Address,
+) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.WithdrawPayment(opts, transmitter, to)
+	})
+}
+
+// WithdrawOwnerFunds sends the LINK collected by the registry as owner premium to the deployer. The deployer must be
+// the registry owner.
+func (d *RegistryV21Deployable) WithdrawOwnerFunds(ctx context.Context, deployer *Deployer) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.WithdrawOwnerFunds(opts)
+	})
+}
+
+// RecoverFunds sends LINK held by the registry in excess of the expected balance to the deployer. The deployer must
+// be the registry owner.
+func (d *RegistryV21Deployable) RecoverFunds(ctx context.Context, deployer *Deployer) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.RecoverFunds(opts)
+	})
+}
@@ -0,0 +1,421 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	automationutils "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/automation_utils_2_1"
+
+	"github.com/easterthebunny/automation-cli/internal/util"
+)
+
+const (
+	ConditionalTrigger uint8 = 0
+	LogTrigger         uint8 = 1
+
+	// activeUpkeepPageSize is the number of upkeep ids read from the registry per call
+	activeUpkeepPageSize int64 = 500
+	// upkeepReadWorkers is the number of upkeeps read from the registry concurrently
+	upkeepReadWorkers = 10
+)
+
+//nolint:gochecknoglobals
+var uint256Type, _ = abi.NewType("uint256", "", nil)
+
+// UpkeepDetail is the registry state of a single upkeep.
+type UpkeepDetail struct {
+	ID                 *big.Int          `json:"id"`
+	Target             common.Address    `json:"target"`
+	Admin              common.Address    `json:"admin"`
+	Forwarder          common.Address    `json:"forwarder"`
+	Balance            *big.Int          `json:"balance"`
+	MinBalance         *big.Int          `json:"minBalance"`
+	AmountSpent        *big.Int          `json:"amountSpent"`
+	TriggerType        string            `json:"triggerType"`
+	LogTriggerConfig   *LogTriggerConfig `json:"logTriggerConfig,omitempty"`
+	TriggerConfig      hexutil.Bytes     `json:"triggerConfig"`
+	GasLimit           uint32            `json:"gasLimit"`
+	CheckData          hexutil.Bytes     `json:"checkData"`
+	OffchainConfig     hexutil.Bytes     `json:"offchainConfig"`
+	Paused             bool              `json:"paused"`
+	Cancelled          bool              `json:"cancelled"`
+	MaxValidBlock      uint64            `json:"maxValidBlock"`
+	LastPerformedBlock uint32            `json:"lastPerformedBlock"`
+}
+
+// LogTriggerConfig is the filter a log trigger upkeep is checked for. The filter selector is a bit mask where bits 0
+// to 2 enable filtering on topics 1 to 3.
+type LogTriggerConfig struct {
+	ContractAddress common.Address `json:"contractAddress"`
+	FilterSelector  uint8          `json:"filterSelector"`
+	Topic0          common.Hash    `json:"topic0"`
+	Topic1          common.Hash    `json:"topic1"`
+	Topic2          common.Hash    `json:"topic2"`
+	Topic3          common.Hash    `json:"topic3"`
+}
+
+// RegistryState is the registry wide state and totals.
+type RegistryState struct {
+	Address             common.Address   `json:"address"`
+	TypeAndVersion      string           `json:"typeAndVersion"`
+	Owner               common.Address   `json:"owner"`
+	Paused              bool             `json:"paused"`
+	NumUpkeeps          *big.Int         `json:"numUpkeeps"`
+	OwnerLinkBalance    *big.Int         `json:"ownerLinkBalance"`
+	ExpectedLinkBalance *big.Int         `json:"expectedLinkBalance"`
+	TotalPremium        *big.Int         `json:"totalPremium"`
+	Nonce               uint32           `json:"nonce"`
+	ConfigCount         uint32           `json:"configCount"`
+	LatestConfigBlock   uint32           `json:"latestConfigBlock"`
+	LatestConfigDigest  common.Hash      `json:"latestConfigDigest"`
+	LatestEpoch         uint32           `json:"latestEpoch"`
+	F                   uint8            `json:"f"`
+	Signers             []common.Address `json:"signers"`
+	Transmitters        []common.Address `json:"transmitters"`
+}
+
+// Upkeep reads the registry state of a single upkeep.
+func (d *RegistryV21Deployable) Upkeep(ctx context.Context, deployer *Deployer, id *big.Int) (UpkeepDetail, error) {
+	opts := &bind.CallOpts{Context: ctx, From: deployer.Address}
+
+	info, err := d.registry.GetUpkeep(opts, id)
+	if err != nil {
+		return UpkeepDetail{}, fmt.Errorf("%w: failed to get upkeep %s: %s", ErrContractConnection, id, err.Error())
+	}
+
+	detail := UpkeepDetail{
+		ID:                 id,
+		Target:             info.Target,
+		Admin:              info.Admin,
+		Balance:            info.Balance,
+		AmountSpent:        info.AmountSpent,
+		GasLimit:           info.PerformGas,
+		CheckData:          info.CheckData,
+		OffchainConfig:     info.OffchainConfig,
+		Paused:             info.Paused,
+		Cancelled:          info.MaxValidBlocknumber != math.MaxUint32,
+		MaxValidBlock:      info.MaxValidBlocknumber,
+		LastPerformedBlock: info.LastPerformedBlockNumber,
+	}
+
+	if detail.MinBalance, err = d.registry.GetMinBalance(opts, id); err != nil {
+		return detail, fmt.Errorf("%w: failed to get min balance: %s", ErrContractConnection, err.Error())
+	}
+
+	if detail.Forwarder, err = d.registry.GetForwarder(opts, id); err != nil {
+		return detail, fmt.Errorf("%w: failed to get forwarder: %s", ErrContractConnection, err.Error())
+	}
+
+	triggerType, err := d.registry.GetTriggerType(opts, id)
+	if err != nil {
+		return detail, fmt.Errorf("%w: failed to get trigger type: %s", ErrContractConnection, err.Error())
+	}
+
+	detail.TriggerType = TriggerTypeName(triggerType)
+
+	if detail.TriggerConfig, err = d.registry.GetUpkeepTriggerConfig(opts, id); err != nil {
+		return detail, fmt.Errorf("%w: failed to get trigger config: %s", ErrContractConnection, err.Error())
+	}
+
+	if triggerType == LogTrigger && len(detail.TriggerConfig) > 0 {
+		logConfig, err := DecodeLogTriggerConfig(detail.TriggerConfig)
+		if err != nil {
+			return detail, err
+		}
+
+		detail.LogTriggerConfig = &logConfig
+	}
+
+	return detail, nil
+}
+
+// ActiveUpkeepIDs reads the ids of all active upkeeps on the registry.
+func (d *RegistryV21Deployable) ActiveUpkeepIDs(ctx context.Context, deployer *Deployer) ([]*big.Int, error) {
+	opts := &bind.CallOpts{Context: ctx, From: deployer.Address}
+	upkeepIDs := make([]*big.Int, 0)
+
+	for start := int64(0); ; start += activeUpkeepPageSize {
+		page, err := d.registry.GetActiveUpkeepIDs(opts, big.NewInt(start), big.NewInt(activeUpkeepPageSize))
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get active upkeep ids: %s", ErrContractConnection, err.Error())
+		}
+
+		upkeepIDs = append(upkeepIDs, page...)
+
+		if int64(len(page)) < activeUpkeepPageSize {
+			return upkeepIDs, nil
+		}
+	}
+}
+
+// Upkeeps reads the registry state of the provided upkeeps concurrently. Results are ordered by upkeep id.
+func (d *RegistryV21Deployable) Upkeeps(
+	ctx context.Context,
+	deployer *Deployer,
+	upkeepIDs []*big.Int,
+) ([]UpkeepDetail, error) {
+	type result struct {
+		detail UpkeepDetail
+		err    error
+	}
+
+	jobs := make([]util.Job[result], len(upkeepIDs))
+
+	for idx := range upkeepIDs {
+		id := upkeepIDs[idx]
+
+		jobs[idx] = func(ctx context.Context) result {
+			detail, err := d.Upkeep(ctx, deployer, id)
+
+			return result{detail: detail, err: err}
+		}
+	}
+
+	details := make([]UpkeepDetail, 0, len(upkeepIDs))
+
+	for _, res := range util.NewParallel[result](upkeepReadWorkers).RunWithContext(ctx, jobs) {
+		if res.err != nil {
+			return nil, res.err
+		}
+
+		details = append(details, res.detail)
+	}
+
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID.Cmp(details[j].ID) < 0
+	})
+
+	return details, ctx.Err()
+}
+
+// State reads the registry wide state and totals.
+func (d *RegistryV21Deployable) State(ctx context.Context, deployer *Deployer) (RegistryState, error) {
+	opts := &bind.CallOpts{Context: ctx, From: deployer.Address}
+
+	state, err := d.registry.GetState(opts)
+	if err != nil {
+		return RegistryState{}, fmt.Errorf("%w: failed to get registry state: %s", ErrContractConnection, err.Error())
+	}
+
+	typeAndVersion, err := d.registry.TypeAndVersion(opts)
+	if err != nil {
+		return RegistryState{}, fmt.Errorf("%w: failed to get type and version: %s", ErrContractConnection, err.Error())
+	}
+
+	owner, err := d.registry.Owner(opts)
+	if err != nil {
+		return RegistryState{}, fmt.Errorf("%w: failed to get owner: %s", ErrContractConnection, err.Error())
+	}
+
+	return RegistryState{
+		Address:             common.HexToAddress(d.rCfg.Address),
+		TypeAndVersion:      typeAndVersion,
+		Owner:               owner,
+		Paused:              state.State.Paused,
+		NumUpkeeps:          state.State.NumUpkeeps,
+		OwnerLinkBalance:    state.State.OwnerLinkBalance,
+		ExpectedLinkBalance: state.State.ExpectedLinkBalance,
+		TotalPremium:        state.State.TotalPremium,
+		Nonce:               state.State.Nonce,
+		ConfigCount:         state.State.ConfigCount,
+		LatestConfigBlock:   state.State.LatestConfigBlockNumber,
+		LatestConfigDigest:  state.State.LatestConfigDigest,
+		LatestEpoch:         state.State.LatestEpoch,
+		F:                   state.F,
+		Signers:             state.Signers,
+		Transmitters:        state.Transmitters,
+	}, nil
+}
+
+// DecodeLogTriggerConfig decodes the trigger config of a log trigger upkeep.
+func DecodeLogTriggerConfig(raw []byte) (LogTriggerConfig, error) {
+	args, err := logTriggerConfigArgs()
+	if err != nil {
+		return LogTriggerConfig{}, err
+	}
+
+	values, err := args.Unpack(raw)
+	if err != nil {
+		return LogTriggerConfig{}, fmt.Errorf("%w: failed to decode log trigger config: %s", ErrConfiguration, err.Error())
+	}
+
+	if len(values) != 1 {
+		return LogTriggerConfig{}, fmt.Errorf("%w: unexpected log trigger config values", ErrConfiguration)
+	}
+
+	decoded, ok := abi.ConvertType(values[0], new(automationutils.LogTriggerConfig)).(*automationutils.LogTriggerConfig)
+	if !ok {
+		return LogTriggerConfig{}, fmt.Errorf("%w: unexpected log trigger config type", ErrConfiguration)
+	}
+
+	return LogTriggerConfig{
+		ContractAddress: decoded.ContractAddress,
+		FilterSelector:  decoded.FilterSelector,
+		Topic0:          decoded.Topic0,
+		Topic1:          decoded.Topic1,
+		Topic2:          decoded.Topic2,
+		Topic3:          decoded.Topic3,
+	}, nil
+}
+
+// EncodeLogTriggerConfig encodes the trigger config of a log trigger upkeep.
+func EncodeLogTriggerConfig(conf LogTriggerConfig) ([]byte, error) {
+	args, err := logTriggerConfigArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := args.Pack(automationutils.LogTriggerConfig{
+		ContractAddress: conf.ContractAddress,
+		FilterSelector:  conf.FilterSelector,
+		Topic0:          conf.Topic0,
+		Topic1:          conf.Topic1,
+		Topic2:          conf.Topic2,
+		Topic3:          conf.Topic3,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode log trigger config: %s", ErrConfiguration, err.Error())
+	}
+
+	return encoded, nil
+}
+
+func logTriggerConfigArgs() (abi.Arguments, error) {
+	utilsABI, err := automationutils.AutomationUtilsMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse automation utils abi: %s", ErrConfiguration, err.Error())
+	}
+
+	return utilsABI.Methods["_logTriggerConfig"].Inputs, nil
+}
+
+// TriggerTypeName returns the display name of an upkeep trigger type.
+func TriggerTypeName(triggerType uint8) string {
+	switch triggerType {
+	case ConditionalTrigger:
+		return "conditional"
+	case LogTrigger:
+		return "log"
+	default:
+		return fmt.Sprintf("unknown (%d)", triggerType)
+	}
+}
+
+// AddFunds sends LINK from the deployer to the registry for the upkeep using transferAndCall.
+func (d *RegistryV21Deployable) AddFunds(ctx context.Context, deployer *Deployer, id, amount *big.Int) error {
+	if deployer.linkToken == nil {
+		return fmt.Errorf("%w: link token required to add funds", ErrConfiguration)
+	}
+
+	encodedID, err := abi.Arguments{{Type: uint256Type}}.Pack(id)
+	if err != nil {
+		return fmt.Errorf("%w: failed to encode upkeep id: %s", ErrConfiguration, err.Error())
+	}
+
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return deployer.linkToken.TransferAndCall(opts, common.HexToAddress(d.rCfg.Address), amount, encodedID)
+	})
+}
+
+func (d *RegistryV21Deployable) PauseUpkeep(ctx context.Context, deployer *Deployer, id *big.Int) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.PauseUpkeep(opts, id)
+	})
+}
+
+func (d *RegistryV21Deployable) UnpauseUpkeep(ctx context.Context, deployer *Deployer, id *big.Int) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.UnpauseUpkeep(opts, id)
+	})
+}
+
+func (d *RegistryV21Deployable) CancelUpkeep(ctx context.Context, deployer *Deployer, id *big.Int) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.CancelUpkeep(opts, id)
+	})
+}
+
+// WithdrawFunds withdraws the balance of a cancelled upkeep to the provided address. Funds are available after the
+// registry cancellation delay.
+func (d *RegistryV21Deployable) WithdrawFunds(
+	ctx context.Context,
+	deployer *Deployer,
+	id *big.Int,
+	to common.Address,
+) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.WithdrawFunds(opts, id, to)
+	})
+}
+
+func (d *RegistryV21Deployable) SetUpkeepGasLimit(
+	ctx context.Context,
+	deployer *Deployer,
+	id *big.Int,
+	gasLimit uint32,
+) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.SetUpkeepGasLimit(opts, id, gasLimit)
+	})
+}
+
+func (d *RegistryV21Deployable) SetUpkeepCheckData(
+	ctx context.Context,
+	deployer *Deployer,
+	id *big.Int,
+	checkData []byte,
+) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.SetUpkeepCheckData(opts, id, checkData)
+	})
+}
+
+func (d *RegistryV21Deployable) SetUpkeepTriggerConfig(
+	ctx context.Context,
+	deployer *Deployer,
+	id *big.Int,
+	triggerConfig []byte,
+) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.SetUpkeepTriggerConfig(opts, id, triggerConfig)
+	})
+}
+
+func (d *RegistryV21Deployable) SetUpkeepOffchainConfig(
+	ctx context.Context,
+	deployer *Deployer,
+	id *big.Int,
+	offchainConfig []byte,
+) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.SetUpkeepOffchainConfig(opts, id, offchainConfig)
+	})
+}
+
+// TransferUpkeepAdmin proposes a new admin for the upkeep. The transfer completes when the proposed admin accepts.
+func (d *RegistryV21Deployable) TransferUpkeepAdmin(
+	ctx context.Context,
+	deployer *Deployer,
+	id *big.Int,
+	proposed common.Address,
+) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.TransferUpkeepAdmin(opts, id, proposed)
+	})
+}
+
+// AcceptUpkeepAdmin accepts a proposed admin transfer where the deployer is the proposed admin.
+func (d *RegistryV21Deployable) AcceptUpkeepAdmin(ctx context.Context, deployer *Deployer, id *big.Int) error {
+	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return d.registry.AcceptUpkeepAdmin(opts, id)
+	})
+}
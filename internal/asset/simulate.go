@@ -0,0 +1,129 @@
+package asset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/easterthebunny/automation-cli/internal/decode"
+	cliio "github.com/easterthebunny/automation-cli/internal/io"
+)
+
+// simulating reports whether transactions are simulated instead of sent.
+func simulating(ctx context.Context) bool {
+	return cliio.DryRunFromContext(ctx)
+}
+
+// applySimulation prepares transaction options such that transactions are simulated against the latest block when
+// they are signed and are never sent. The gas limit is set to the block gas limit to keep the contract bindings from
+// failing on gas estimation before the simulation can report the revert reason.
+func (d *Deployer) applySimulation(ctx context.Context, opts *bind.TransactOpts) error {
+	header, err := d.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to get latest header: %s", ErrClientInteraction, err.Error())
+	}
+
+	signer := opts.Signer
+
+	opts.NoSend = true
+	opts.GasLimit = header.GasLimit
+	opts.Signer = func(addr common.Address, trx *types.Transaction) (*types.Transaction, error) {
+		d.simulate(ctx, addr, trx)
+
+		return signer(addr, trx)
+	}
+
+	return nil
+}
+
+// simulate runs the transaction as a call and estimates gas when the call succeeds. The target, decoded calldata,
+// estimated gas and cost, or the revert reason are printed.
+func (d *Deployer) simulate(ctx context.Context, from common.Address, trx *types.Transaction) {
+	out := os.Stdout
+
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    trx.To(),
+		Value: trx.Value(),
+		Data:  trx.Data(),
+	}
+
+	fmt.Fprintf(out, "dry run: transaction at nonce %d not sent\n", trx.Nonce())
+	fmt.Fprintf(out, "  from:   %s\n", from)
+
+	if trx.To() == nil {
+		fmt.Fprintf(out, "  target: new contract at %s\n", crypto.CreateAddress(from, trx.Nonce()))
+		fmt.Fprintf(out, "  method: %s\n", decode.MethodDeploy)
+	} else {
+		fmt.Fprintf(out, "  target: %s\n", trx.To())
+
+		if call, err := decode.Method(trx.Data()); err == nil {
+			fmt.Fprintf(out, "  method: %s.%s\n", call.Contract, call.Method)
+
+			for _, arg := range decode.FormatArgs(call.Args) {
+				fmt.Fprintf(out, "    %s\n", arg)
+			}
+		} else {
+			fmt.Fprintf(out, "  method: %s\n", decode.MethodName(trx))
+		}
+	}
+
+	if trx.Value().Sign() > 0 {
+		fmt.Fprintf(out, "  value:  %s wei\n", trx.Value())
+	}
+
+	if _, err := d.Client.CallContract(ctx, msg, nil); err != nil {
+		fmt.Fprintf(out, "  revert: %s\n", revertReason(err))
+
+		return
+	}
+
+	gas, err := d.Client.EstimateGas(ctx, msg)
+	if err != nil {
+		fmt.Fprintf(out, "  revert: %s\n", revertReason(err))
+
+		return
+	}
+
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(gas), feeOf(trx))
+	eth := new(big.Float).Quo(new(big.Float).SetInt(cost), big.NewFloat(params.Ether))
+
+	fmt.Fprintf(out, "  gas:    %d\n", gas)
+	fmt.Fprintf(out, "  cost:   %s wei (%s ETH) at most\n", cost, eth.Text('f', 8))
+}
+
+// revertReason decodes the revert data of a call error where available and falls back to the error message.
+func revertReason(err error) string {
+	var dataErr interface{ ErrorData() interface{} }
+
+	if !errors.As(err, &dataErr) {
+		return err.Error()
+	}
+
+	encoded, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err.Error()
+	}
+
+	data, decodeErr := hexutil.Decode(encoded)
+	if decodeErr != nil {
+		return err.Error()
+	}
+
+	reason, decodeErr := decode.Revert(data)
+	if decodeErr != nil {
+		return fmt.Sprintf("%s (%s)", err.Error(), encoded)
+	}
+
+	return reason
+}
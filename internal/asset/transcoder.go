@@ -0,0 +1,32 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	transcoder "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/upkeep_transcoder"
+)
+
+// DeployUpkeepTranscoder deploys the transcoder a registry passes migrated upkeeps through. The transcoder only
+// accepts upkeeps that are migrated between registries of the same upkeep version.
+func DeployUpkeepTranscoder(ctx context.Context, deployer *Deployer) (common.Address, error) {
+	var contractAddr common.Address
+
+	opts, err := deployer.BuildTxOpts(ctx)
+	if err != nil {
+		return contractAddr, fmt.Errorf("%w: deploy failed: %s", ErrContractCreate, err.Error())
+	}
+
+	contractAddr, trx, _, err := transcoder.DeployUpkeepTranscoder(opts, deployer.Client)
+	if err != nil {
+		return contractAddr, fmt.Errorf("%w: UpkeepTranscoder creation failed: %s", ErrContractCreate, err.Error())
+	}
+
+	if err := deployer.waitDeployment(ctx, trx); err != nil {
+		return contractAddr, err
+	}
+
+	return contractAddr, nil
+}
@@ -4,42 +4,26 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
 	"math/big"
 	"sort"
 	"sync"
 	"time"
-	"unicode/utf8"
 
 	"github.com/easterthebunny/automation-cli/internal/config"
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
 	"github.com/easterthebunny/automation-cli/internal/util"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/jedib0t/go-pretty/v6/table"
-	"github.com/montanaflynn/stats"
 
 	verifiableLogTrigger "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/verifiable_load_log_trigger_upkeep_wrapper"
 	verifiableConditional "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/verifiable_load_upkeep_wrapper"
 )
 
-var (
-	ErrContractRead       = fmt.Errorf("contract read error")
-	ErrCalculationFailure = fmt.Errorf("calculation failure")
-	//nolint:gochecknoglobals
-	headerRowOne = table.Row{
-		"ID", "Total Performs", "Block Delays", "Block Delays", "Block Delays",
-		"Block Delays", "Block Delays", "Block Delays", "Block Delays"}
-	//nolint:gochecknoglobals
-	headerRowTwo = table.Row{
-		"", "", "50th", "90th", "95th", "99th", "Max", "Total", "Average"}
-)
+var ErrContractRead = fmt.Errorf("contract read error")
 
 const (
-	P50                   float64 = 50
-	P90                   float64 = 90
-	P95                   float64 = 95
-	P99                   float64 = 99
-	percentilesCalculated         = 4
 	// workerNum is the total number of workers calculating upkeeps' delay summary
 	workerNum = 20
 	// retryDelay is the time the go routine will wait before calling the same contract function
@@ -48,7 +32,6 @@ const (
 	retryNum = 3
 	// batchSize is the maximum number of upkeeps to register for each batch.
 	batchSize             uint8 = 20
-	upkeepIDLength        int   = 8
 	conditionalUpkeepType uint8 = 0
 	logtriggerUpkeepType  uint8 = 1
 	DefaultRegisterAmount       = 10_000_000_000_000_000
@@ -62,6 +45,12 @@ type VerifiableLoadInteractionConfig struct {
 	RegisteredUpkeepInterval uint32
 	CancelBeforeRegister     bool
 	SendLINKBeforeRegister   bool
+	// stats are limited to performs after the since block or within the window before the latest block and to the
+	// last buckets of each upkeep when set
+	StatsSinceBlock  uint64
+	StatsWindow      time.Duration
+	StatsLastBuckets uint16
+	StatsBreakdown   bool
 }
 
 type VerifiableLoadLogTriggerDeployable struct {
@@ -78,12 +67,27 @@ func NewVerifiableLoadLogTriggerDeployable(
 		return nil, fmt.Errorf("%w: unvalid config for log trigger load contract", ErrConfiguration)
 	}
 
+	if err := checkLoadRegistrar(registrar); err != nil {
+		return nil, err
+	}
+
 	return &VerifiableLoadLogTriggerDeployable{
 		registrar: registrar,
 		cCfg:      conf,
 	}, nil
 }
 
+// checkLoadRegistrar returns an error when the registrar cannot register the upkeeps of a load contract. Load
+// contracts register through the v2.1 registrar interface.
+func checkLoadRegistrar(registrar config.AutomationRegistrarV21Contract) error {
+	if version := registrar.ContractVersion(); version != config.RegistryVersion21 {
+		return fmt.Errorf("%w: verifiable load contracts require a v2.1 registrar; found %s",
+			ErrUnsupportedVersion, version)
+	}
+
+	return nil
+}
+
 func (d *VerifiableLoadLogTriggerDeployable) Connect(
 	ctx context.Context,
 	deployer *Deployer,
@@ -134,18 +138,19 @@ func (d *VerifiableLoadLogTriggerDeployable) ReadStats(
 	ctx context.Context,
 	deployer *Deployer,
 	conf VerifiableLoadInteractionConfig,
-) error {
+) (loadstats.Snapshot, error) {
 	addr := common.HexToAddress(d.cCfg.Address)
 
 	contract, err := verifiableLogTrigger.NewVerifiableLoadLogTriggerUpkeep(addr, deployer.Client)
 	if err != nil {
-		return fmt.Errorf("failed to create a new verifiable load upkeep from address %s: %v", addr, err)
+		return loadstats.Snapshot{}, fmt.Errorf(
+			"failed to create a new verifiable load upkeep from address %s: %v", addr, err)
 	}
 
 	// get all the stats from this block
 	blockNum, err := deployer.Client.BlockNumber(ctx)
 	if err != nil {
-		return fmt.Errorf("%w: failed to get block number: %s", ErrContractRead, err.Error())
+		return loadstats.Snapshot{}, fmt.Errorf("%w: failed to get block number: %s", ErrContractRead, err.Error())
 	}
 
 	opts := &bind.CallOpts{
@@ -157,10 +162,21 @@ func (d *VerifiableLoadLogTriggerDeployable) ReadStats(
 	// get all active upkeep IDs on this verifiable load contract
 	upkeepIds, err := contract.GetActiveUpkeepIDsDeployedByThisContract(opts, big.NewInt(0), big.NewInt(0))
 	if err != nil {
-		return fmt.Errorf("%w: failed to get active upkeep IDs from %s: %s", ErrContractRead, addr, err.Error())
+		return loadstats.Snapshot{}, fmt.Errorf(
+			"%w: failed to get active upkeep IDs from %s: %s", ErrContractRead, addr, err.Error())
 	}
 
-	return collectAndWriteStats(ctx, contract, upkeepIds, blockNum, opts)
+	if len(upkeepIds) == 0 {
+		return loadstats.Snapshot{}, fmt.Errorf("%w: no upkeeps registered", ErrContractRead)
+	}
+
+	selection, err := selectStats(ctx, deployer, contract, blockNum, conf)
+	if err != nil {
+		return loadstats.Snapshot{}, err
+	}
+
+	return collectStats(
+		ctx, contract, d.cCfg.Address, string(config.LogTriggerLoad), upkeepIds, blockNum, opts, selection)
 }
 
 //nolint:cyclop
@@ -196,7 +212,11 @@ func (d *VerifiableLoadLogTriggerDeployable) RegisterUpkeeps(
 		return fmt.Errorf("%w: contract query failed: %s", ErrContractConnection, err.Error())
 	}
 
-	var offset int
+	var (
+		offset    int
+		intervals []func(*bind.TransactOpts) (*types.Transaction, error)
+		prepares  []func(*bind.TransactOpts) (*types.Transaction, error)
+	)
 
 	upkeepIDBatchSize := 25
 
@@ -210,31 +230,37 @@ func (d *VerifiableLoadLogTriggerDeployable) RegisterUpkeeps(
 			slice = upkeepIDs[offset : offset+upkeepIDBatchSize]
 		}
 
-		if err := runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		intervals = append(intervals, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 			trx, err := contract.BatchSetIntervals(opts, slice, conf.RegisteredUpkeepInterval)
 			if err != nil {
 				return nil, fmt.Errorf("%w: transaction failed: %s", ErrContractConnection, err.Error())
 			}
 
 			return trx, nil
-		}); err != nil {
-			return err
-		}
+		})
 
-		if err := runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		prepares = append(prepares, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 			trx, err := contract.BatchPreparingUpkeepsSimple(opts, slice, 0, 0)
 			if err != nil {
 				return nil, fmt.Errorf("%w: transaction failed: %s", ErrContractConnection, err.Error())
 			}
 
 			return trx, nil
-		}); err != nil {
-			return err
-		}
+		})
 
 		offset += upkeepIDBatchSize
 	}
 
+	// batches of the same call are submitted together, but upkeeps are only prepared once all intervals are mined and
+	// logs are only sent once every upkeep is prepared such that gas is estimated against the updated state
+	if err := runContractFuncs(ctx, deployer, intervals...); err != nil {
+		return err
+	}
+
+	if err := runContractFuncs(ctx, deployer, prepares...); err != nil {
+		return err
+	}
+
 	return runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		trx, err := contract.BatchSendLogs(opts, 0)
 		if err != nil {
@@ -264,6 +290,48 @@ func (d *VerifiableLoadLogTriggerDeployable) CancelUpkeeps(
 	return nil
 }
 
+// ActiveUpkeepIDs returns the ids of all active upkeeps registered by the load contract.
+func (d *VerifiableLoadLogTriggerDeployable) ActiveUpkeepIDs(
+	ctx context.Context,
+	deployer *Deployer,
+) ([]*big.Int, error) {
+	addr := common.HexToAddress(d.cCfg.Address)
+
+	contract, err := verifiableLogTrigger.NewVerifiableLoadLogTriggerUpkeep(addr, deployer.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a new verifiable load upkeep from address %s: %v", addr, err)
+	}
+
+	return getActiveUpkeepIDs(ctx, contract, deployer)
+}
+
+// WithdrawLINK withdraws the balances of the provided cancelled upkeeps to the load contract and then transfers the
+// full LINK balance of the load contract to the deployer. The deployer must be the owner of the load contract.
+func (d *VerifiableLoadLogTriggerDeployable) WithdrawLINK(
+	ctx context.Context,
+	deployer *Deployer,
+	upkeepIDs []*big.Int,
+) error {
+	addr := common.HexToAddress(d.cCfg.Address)
+
+	contract, err := verifiableLogTrigger.NewVerifiableLoadLogTriggerUpkeep(addr, deployer.Client)
+	if err != nil {
+		return fmt.Errorf("failed to create a new verifiable load upkeep from address %s: %v", addr, err)
+	}
+
+	maxValidBlock := func(opts *bind.CallOpts, upkeepID *big.Int) (uint64, error) {
+		info, err := contract.GetUpkeepInfo(opts, upkeepID)
+
+		return info.MaxValidBlocknumber, err
+	}
+
+	if err := withdrawAllLINK(ctx, contract, deployer, upkeepIDs, maxValidBlock); err != nil {
+		return fmt.Errorf("%w: failed to withdraw LINK: %s", ErrContractConnection, err.Error())
+	}
+
+	return nil
+}
+
 func (d *VerifiableLoadLogTriggerDeployable) connectToInterface(
 	_ context.Context,
 	addr common.Address,
@@ -293,6 +361,10 @@ func NewVerifiableLoadConditionalDeployable(
 		return nil, fmt.Errorf("%w: invalid load type config", ErrConfiguration)
 	}
 
+	if err := checkLoadRegistrar(registrar); err != nil {
+		return nil, err
+	}
+
 	return &VerifiableLoadConditionalDeployable{
 		registrar: registrar,
 		cCfg:      conf,
@@ -352,18 +424,19 @@ func (d *VerifiableLoadConditionalDeployable) ReadStats(
 	ctx context.Context,
 	deployer *Deployer,
 	conf VerifiableLoadInteractionConfig,
-) error {
+) (loadstats.Snapshot, error) {
 	addr := common.HexToAddress(d.cCfg.Address)
 
 	contract, err := verifiableConditional.NewVerifiableLoadUpkeep(addr, deployer.Client)
 	if err != nil {
-		return fmt.Errorf("failed to create a new verifiable load upkeep from address %s: %v", addr, err)
+		return loadstats.Snapshot{}, fmt.Errorf(
+			"failed to create a new verifiable load upkeep from address %s: %v", addr, err)
 	}
 
 	// get all the stats from this block
 	blockNum, err := deployer.Client.BlockNumber(ctx)
 	if err != nil {
-		return fmt.Errorf("%w: failed to get block number: %s", ErrContractRead, err.Error())
+		return loadstats.Snapshot{}, fmt.Errorf("%w: failed to get block number: %s", ErrContractRead, err.Error())
 	}
 
 	opts := &bind.CallOpts{
@@ -375,14 +448,21 @@ func (d *VerifiableLoadConditionalDeployable) ReadStats(
 	// get all active upkeep IDs on this verifiable load contract
 	upkeepIds, err := contract.GetActiveUpkeepIDsDeployedByThisContract(opts, big.NewInt(0), big.NewInt(0))
 	if err != nil {
-		return fmt.Errorf("%w: failed to get active upkeep IDs from %s: %s", ErrContractRead, addr, err.Error())
+		return loadstats.Snapshot{}, fmt.Errorf(
+			"%w: failed to get active upkeep IDs from %s: %s", ErrContractRead, addr, err.Error())
 	}
 
 	if len(upkeepIds) == 0 {
-		return fmt.Errorf("%w: no upkeeps registered", ErrContractRead)
+		return loadstats.Snapshot{}, fmt.Errorf("%w: no upkeeps registered", ErrContractRead)
 	}
 
-	return collectAndWriteStats(ctx, contract, upkeepIds, blockNum, opts)
+	selection, err := selectStats(ctx, deployer, contract, blockNum, conf)
+	if err != nil {
+		return loadstats.Snapshot{}, err
+	}
+
+	return collectStats(
+		ctx, contract, d.cCfg.Address, string(config.ConditionalLoad), upkeepIds, blockNum, opts, selection)
 }
 
 func (d *VerifiableLoadConditionalDeployable) RegisterUpkeeps(
@@ -417,6 +497,7 @@ func (d *VerifiableLoadConditionalDeployable) RegisterUpkeeps(
 		return fmt.Errorf("%w: failed to register upkeeps: %s", ErrContractConnection, err.Error())
 	}
 
+	// pipeline data is updated once the intervals are mined such that gas is estimated against the updated state
 	if err := runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		trx, err := contract.BatchSetIntervals(opts, upkeepIDs, conf.RegisteredUpkeepInterval)
 		if err != nil {
@@ -457,6 +538,48 @@ func (d *VerifiableLoadConditionalDeployable) CancelUpkeeps(
 	return nil
 }
 
+// ActiveUpkeepIDs returns the ids of all active upkeeps registered by the load contract.
+func (d *VerifiableLoadConditionalDeployable) ActiveUpkeepIDs(
+	ctx context.Context,
+	deployer *Deployer,
+) ([]*big.Int, error) {
+	addr := common.HexToAddress(d.cCfg.Address)
+
+	contract, err := verifiableConditional.NewVerifiableLoadUpkeep(addr, deployer.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a new verifiable load upkeep from address %s: %v", addr, err)
+	}
+
+	return getActiveUpkeepIDs(ctx, contract, deployer)
+}
+
+// WithdrawLINK withdraws the balances of the provided cancelled upkeeps to the load contract and then transfers the
+// full LINK balance of the load contract to the deployer. The deployer must be the owner of the load contract.
+func (d *VerifiableLoadConditionalDeployable) WithdrawLINK(
+	ctx context.Context,
+	deployer *Deployer,
+	upkeepIDs []*big.Int,
+) error {
+	addr := common.HexToAddress(d.cCfg.Address)
+
+	contract, err := verifiableConditional.NewVerifiableLoadUpkeep(addr, deployer.Client)
+	if err != nil {
+		return fmt.Errorf("failed to create a new verifiable load upkeep from address %s: %v", addr, err)
+	}
+
+	maxValidBlock := func(opts *bind.CallOpts, upkeepID *big.Int) (uint64, error) {
+		info, err := contract.GetUpkeepInfo(opts, upkeepID)
+
+		return info.MaxValidBlocknumber, err
+	}
+
+	if err := withdrawAllLINK(ctx, contract, deployer, upkeepIDs, maxValidBlock); err != nil {
+		return fmt.Errorf("%w: failed to withdraw LINK: %s", ErrContractConnection, err.Error())
+	}
+
+	return nil
+}
+
 func (d *VerifiableLoadConditionalDeployable) connectToInterface(
 	_ context.Context,
 	addr common.Address,
@@ -477,9 +600,11 @@ type upkeepInfo struct {
 	ID              *big.Int
 	Bucket          uint16
 	DelayBuckets    map[uint16][]float64
+	Selected        []loadstats.BucketDelays
 	SortedAllDelays []float64
 	TotalDelayBlock float64
 	TotalPerforms   uint64
+	Err             error
 }
 
 func (ui *upkeepInfo) AddBucket(bucketNum uint16, bucketDelays []float64) {
@@ -488,169 +613,215 @@ func (ui *upkeepInfo) AddBucket(bucketNum uint16, bucketDelays []float64) {
 	ui.DelayBuckets[bucketNum] = bucketDelays
 }
 
-type upkeepStats struct {
-	BlockNumber     uint64
-	AllInfos        []*upkeepInfo
-	TotalDelayBlock float64
-	TotalPerforms   uint64
-	SortedAllDelays []float64
+// SetErr keeps the first error that occurred while reading the upkeep.
+func (ui *upkeepInfo) SetErr(err error) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	if ui.Err == nil {
+		ui.Err = err
+	}
 }
 
 type verifiableLoadContract interface {
 	Counters(*bind.CallOpts, *big.Int) (*big.Int, error)
 	Buckets(*bind.CallOpts, *big.Int) (uint16, error)
 	GetBucketedDelays(*bind.CallOpts, *big.Int, uint16) ([]*big.Int, error)
+	GetDelaysLength(*bind.CallOpts, *big.Int) (*big.Int, error)
+	BUCKETSIZE(*bind.CallOpts) (uint16, error)
 }
 
-func getUpkeepInfoJob(upkeepID *big.Int, contract verifiableLoadContract, opts *bind.CallOpts) util.Job[*upkeepInfo] {
-	return func(ctx context.Context) *upkeepInfo {
-		// fetch how many times this upkeep has been executed
-		counter, err := contract.Counters(opts, upkeepID)
-		if err != nil {
-			log.Fatalf("failed to get counter for %s: %v", upkeepID.String(), err)
-		}
+// statsSelection limits delay stats to recent performs. Delays are recorded in perform order and buckets before the
+// latest are full, so the delay count of an upkeep at the since block is the index of the first delay after it.
+type statsSelection struct {
+	since       *bind.CallOpts
+	sinceBlock  uint64
+	lastBuckets uint16
+	bucketSize  uint16
+	breakdown   bool
+}
 
-		// get all the buckets of an upkeep. 100 performs is a bucket.
-		bucket, err := contract.Buckets(opts, upkeepID)
+// selectStats resolves the window to a since block and checks that contract state is available at the since block.
+func selectStats(
+	ctx context.Context,
+	deployer *Deployer,
+	contract verifiableLoadContract,
+	blockNum uint64,
+	conf VerifiableLoadInteractionConfig,
+) (statsSelection, error) {
+	selection := statsSelection{
+		sinceBlock:  conf.StatsSinceBlock,
+		lastBuckets: conf.StatsLastBuckets,
+		breakdown:   conf.StatsBreakdown,
+	}
+
+	if conf.StatsWindow > 0 {
+		latest, err := deployer.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNum))
 		if err != nil {
-			log.Fatalf("failed to get current bucket count for %s: %v", upkeepID.String(), err)
+			return selection, fmt.Errorf("%w: failed to get block %d: %s", ErrContractRead, blockNum, err.Error())
 		}
 
-		info := &upkeepInfo{
-			ID:            upkeepID,
-			Bucket:        bucket,
-			TotalPerforms: counter.Uint64(),
-			DelayBuckets:  map[uint16][]float64{},
+		since := time.Unix(int64(latest.Time), 0).Add(-conf.StatsWindow)
+
+		if selection.sinceBlock, err = blockAtTime(ctx, deployer, since, blockNum); err != nil {
+			return selection, err
 		}
+	}
 
-		var (
-			delays []float64
-			group  sync.WaitGroup
-		)
+	if selection.sinceBlock == 0 {
+		return selection, nil
+	}
 
-		for idx := uint16(0); idx <= bucket; idx++ {
-			group.Add(1)
+	if selection.sinceBlock > blockNum {
+		return selection, fmt.Errorf("%w: since block %d is after the latest block %d",
+			ErrContractRead, selection.sinceBlock, blockNum)
+	}
 
-			go func(idx uint16) {
-				defer group.Done()
+	selection.since = &bind.CallOpts{
+		From:        deployer.Address,
+		Context:     ctx,
+		BlockNumber: new(big.Int).SetUint64(selection.sinceBlock),
+	}
 
-				getBucketData(contract, opts, upkeepID, idx, info)
-			}(idx)
-		}
+	bucketSize, err := contract.BUCKETSIZE(selection.since)
+	if err != nil {
+		return selection, fmt.Errorf("%w: failed to read contract at block %d; the rpc may not have state for this "+
+			"block: %s", ErrContractRead, selection.sinceBlock, err.Error())
+	}
 
-		group.Wait()
+	selection.bucketSize = bucketSize
 
-		for i := uint16(0); i <= bucket; i++ {
-			bucketDelays := info.DelayBuckets[i]
-			delays = append(delays, bucketDelays...)
+	return selection, nil
+}
 
-			for _, d := range bucketDelays {
-				info.TotalDelayBlock += d
-			}
+// blockAtTime returns the first block with a timestamp at or after the provided time.
+func blockAtTime(ctx context.Context, deployer *Deployer, at time.Time, latest uint64) (uint64, error) {
+	var searchErr error
+
+	block := sort.Search(int(latest)+1, func(idx int) bool {
+		if searchErr != nil {
+			return true
 		}
 
-		sort.Float64s(delays)
-		info.SortedAllDelays = delays
-		info.TotalPerforms = uint64(len(info.SortedAllDelays))
+		header, err := deployer.Client.HeaderByNumber(ctx, big.NewInt(int64(idx)))
+		if err != nil {
+			searchErr = fmt.Errorf("%w: failed to get block %d: %s", ErrContractRead, idx, err.Error())
 
-		return info
-	}
+			return true
+		}
+
+		return !time.Unix(int64(header.Time), 0).Before(at)
+	})
+
+	return uint64(block), searchErr
 }
 
-//nolint:funlen
-func getUpkeepInfo(
-	writer table.Writer,
-	idChan chan *big.Int,
-	resultsChan chan *upkeepInfo,
+func getUpkeepInfoJob(
+	upkeepID *big.Int,
 	contract verifiableLoadContract,
 	opts *bind.CallOpts,
-) {
-	for upkeepID := range idChan {
+	selection statsSelection,
+) util.Job[*upkeepInfo] {
+	return func(ctx context.Context) *upkeepInfo {
+		info := &upkeepInfo{
+			ID:           upkeepID,
+			DelayBuckets: map[uint16][]float64{},
+		}
+
 		// fetch how many times this upkeep has been executed
 		counter, err := contract.Counters(opts, upkeepID)
 		if err != nil {
-			log.Fatalf("failed to get counter for %s: %v", upkeepID.String(), err)
+			info.Err = fmt.Errorf("%w: failed to get counter for %s: %s", ErrContractRead, upkeepID, err.Error())
+
+			return info
 		}
 
 		// get all the buckets of an upkeep. 100 performs is a bucket.
 		bucket, err := contract.Buckets(opts, upkeepID)
 		if err != nil {
-			log.Fatalf("failed to get current bucket count for %s: %v", upkeepID.String(), err)
+			info.Err = fmt.Errorf("%w: failed to get current bucket count for %s: %s",
+				ErrContractRead, upkeepID, err.Error())
+
+			return info
 		}
 
-		info := &upkeepInfo{
-			ID:            upkeepID,
-			Bucket:        bucket,
-			TotalPerforms: counter.Uint64(),
-			DelayBuckets:  map[uint16][]float64{},
+		info.Bucket = bucket
+		info.TotalPerforms = counter.Uint64()
+
+		limits := loadstats.Selection{LastBuckets: selection.lastBuckets}
+
+		if selection.since != nil {
+			length, err := contract.GetDelaysLength(selection.since, upkeepID)
+			if err != nil {
+				info.Err = fmt.Errorf("%w: failed to get delay count for %s at block %d: %s",
+					ErrContractRead, upkeepID, selection.sinceBlock, err.Error())
+
+				return info
+			}
+
+			limits.Skip = length.Uint64()
+			limits.BucketSize = selection.bucketSize
 		}
 
-		var (
-			delays []float64
-			group  sync.WaitGroup
-		)
+		var group sync.WaitGroup
 
-		for idx := uint16(0); idx <= bucket; idx++ {
+		// buckets that were full at the since block are not fetched
+		for idx := int(limits.FirstBucket(bucket)); idx <= int(bucket); idx++ {
 			group.Add(1)
 
 			go func(idx uint16) {
 				defer group.Done()
 
-				getBucketData(contract, opts, upkeepID, idx, info)
-			}(idx)
+				delays, err := getBucketData(contract, opts, upkeepID, idx)
+				if err != nil {
+					info.SetErr(err)
+
+					return
+				}
+
+				info.AddBucket(idx, delays)
+			}(uint16(idx))
 		}
 
 		group.Wait()
 
-		for i := uint16(0); i <= bucket; i++ {
-			bucketDelays := info.DelayBuckets[i]
-			delays = append(delays, bucketDelays...)
-
-			for _, d := range bucketDelays {
-				info.TotalDelayBlock += d
-			}
+		// a missing bucket would silently change the stats
+		if info.Err != nil {
+			return info
 		}
 
-		sort.Float64s(delays)
-		info.SortedAllDelays = delays
-		info.TotalPerforms = uint64(len(info.SortedAllDelays))
+		if info.Selected, err = limits.Select(info.DelayBuckets, bucket); err != nil {
+			info.Err = fmt.Errorf("upkeep %s: %w", upkeepID, err)
+
+			return info
+		}
 
-		var maxDelay float64
+		var delays []float64
 
-		percentiles := make([]float64, percentilesCalculated)
+		for _, selected := range info.Selected {
+			delays = append(delays, selected.Delays...)
 
-		if len(info.SortedAllDelays) > 0 {
-			percentiles, err = getPercentiles(info.SortedAllDelays, P50, P90, P95, P99)
-			if err != nil {
-				log.Println(err)
+			for _, d := range selected.Delays {
+				info.TotalDelayBlock += d
 			}
-
-			maxDelay = info.SortedAllDelays[len(info.SortedAllDelays)-1]
 		}
 
-		writer.AppendRow(table.Row{
-			shorten(upkeepID.String(), upkeepIDLength),
-			fmt.Sprintf("%d", info.TotalPerforms),
-			fmt.Sprintf("%f", percentiles[0]),
-			fmt.Sprintf("%f", percentiles[1]),
-			fmt.Sprintf("%f", percentiles[2]),
-			fmt.Sprintf("%f", percentiles[3]),
-			fmt.Sprintf("%f", maxDelay),
-			fmt.Sprintf("%d", uint64(info.TotalDelayBlock)),
-			fmt.Sprintf("%f", info.TotalDelayBlock/float64(info.TotalPerforms)),
-		}, table.RowConfig{AutoMerge: true})
+		sort.Float64s(delays)
+		info.SortedAllDelays = delays
+		info.TotalPerforms = uint64(len(info.SortedAllDelays))
 
-		resultsChan <- info
+		return info
 	}
 }
 
+// getBucketData reads the delays of a single bucket in perform order and retries failed reads. An error is returned
+// when every attempt fails.
 func getBucketData(
 	contract verifiableLoadContract,
 	opts *bind.CallOpts,
 	upkeepID *big.Int,
 	bucketNum uint16,
-	info *upkeepInfo,
-) {
+) ([]float64, error) {
 	var (
 		bucketDelays []*big.Int
 		err          error
@@ -658,52 +829,32 @@ func getBucketData(
 
 	for i := 0; i < retryNum; i++ {
 		bucketDelays, err = contract.GetBucketedDelays(opts, upkeepID, bucketNum)
-		if err != nil {
-			log.Printf(
-				"failed to get bucketed delays for upkeep id %s bucket %d: %v, retrying...",
-				upkeepID.String(),
-				bucketNum,
-				err,
-			)
-
-			time.Sleep(retryDelay)
-		} else {
+		if err == nil {
 			break
 		}
-	}
 
-	floatBucketDelays := make([]float64, 0, len(bucketDelays))
+		log.Printf(
+			"failed to get bucketed delays for upkeep id %s bucket %d: %v, retrying...",
+			upkeepID.String(),
+			bucketNum,
+			err,
+		)
 
-	for _, d := range bucketDelays {
-		floatBucketDelays = append(floatBucketDelays, float64(d.Uint64()))
+		time.Sleep(retryDelay)
 	}
 
-	sort.Float64s(floatBucketDelays)
-	info.AddBucket(bucketNum, floatBucketDelays)
-}
-
-func shorten(full string, outLen int) string {
-	if utf8.RuneCountInString(full) < outLen {
-		return full
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get bucketed delays for %s bucket %d after %d attempts: %s",
+			ErrContractRead, upkeepID, bucketNum, retryNum, err.Error())
 	}
 
-	return string([]byte(full)[:outLen])
-}
-
-func getPercentiles(delays []float64, percentiles ...float64) ([]float64, error) {
-	calculated := make([]float64, len(percentiles))
-
-	for idx, percentile := range percentiles {
-		var err error
-
-		calculated[idx], err = stats.Percentile(delays, percentile)
+	floatBucketDelays := make([]float64, 0, len(bucketDelays))
 
-		if err != nil {
-			return nil, fmt.Errorf("%w: %s", ErrCalculationFailure, err.Error())
-		}
+	for _, d := range bucketDelays {
+		floatBucketDelays = append(floatBucketDelays, float64(d.Uint64()))
 	}
 
-	return calculated, nil
+	return floatBucketDelays, nil
 }
 
 type upkeepCanceller interface {
@@ -711,10 +862,14 @@ type upkeepCanceller interface {
 	BatchCancelUpkeeps(*bind.TransactOpts, []*big.Int) (*types.Transaction, error)
 }
 
-func cancelAllUpkeeps(ctx context.Context, canceller upkeepCanceller, deployer *Deployer) error {
+type activeUpkeepLister interface {
+	GetActiveUpkeepIDsDeployedByThisContract(*bind.CallOpts, *big.Int, *big.Int) ([]*big.Int, error)
+}
+
+func getActiveUpkeepIDs(ctx context.Context, lister activeUpkeepLister, deployer *Deployer) ([]*big.Int, error) {
 	// there is likely a limit on the number of upkeeps this function can return
 	// TODO: do this in batches
-	oldUpkeepIds, err := canceller.GetActiveUpkeepIDsDeployedByThisContract(
+	upkeepIDs, err := lister.GetActiveUpkeepIDsDeployedByThisContract(
 		&bind.CallOpts{
 			Context: ctx,
 			From:    deployer.Address,
@@ -723,7 +878,16 @@ func cancelAllUpkeeps(ctx context.Context, canceller upkeepCanceller, deployer *
 		big.NewInt(0),
 	)
 	if err != nil {
-		return fmt.Errorf("%w: contract query failed: %s", ErrContractConnection, err.Error())
+		return nil, fmt.Errorf("%w: contract query failed: %s", ErrContractConnection, err.Error())
+	}
+
+	return upkeepIDs, nil
+}
+
+func cancelAllUpkeeps(ctx context.Context, canceller upkeepCanceller, deployer *Deployer) error {
+	oldUpkeepIds, err := getActiveUpkeepIDs(ctx, canceller, deployer)
+	if err != nil {
+		return err
 	}
 
 	if len(oldUpkeepIds) == 0 {
@@ -748,6 +912,80 @@ func cancelAllUpkeeps(ctx context.Context, canceller upkeepCanceller, deployer *
 	return nil
 }
 
+type linkWithdrawer interface {
+	BatchWithdrawLinks(*bind.TransactOpts, []*big.Int) (*types.Transaction, error)
+	WithdrawLinks(*bind.TransactOpts) (*types.Transaction, error)
+}
+
+// maxValidBlockFunc reads the last block in which an upkeep can be performed. The block is set when the upkeep is
+// cancelled and funds can be withdrawn once it has passed.
+type maxValidBlockFunc func(*bind.CallOpts, *big.Int) (uint64, error)
+
+func withdrawAllLINK(
+	ctx context.Context,
+	withdrawer linkWithdrawer,
+	deployer *Deployer,
+	upkeepIDs []*big.Int,
+	maxValidBlock maxValidBlockFunc,
+) error {
+	if len(upkeepIDs) > 0 {
+		// funds of cancelled upkeeps can only be withdrawn after the registry cancellation delay
+		if !simulating(ctx) {
+			if err := waitCancellation(ctx, deployer, upkeepIDs, maxValidBlock); err != nil {
+				return err
+			}
+		}
+
+		if err := runContractFunc(ctx, deployer, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return withdrawer.BatchWithdrawLinks(opts, upkeepIDs)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return runContractFunc(ctx, deployer, withdrawer.WithdrawLinks)
+}
+
+// waitCancellation waits until the cancellation delay of every upkeep has passed. Upkeeps that were cancelled in an
+// earlier run are usually past their delay already in which case no blocks are waited.
+func waitCancellation(
+	ctx context.Context,
+	deployer *Deployer,
+	upkeepIDs []*big.Int,
+	maxValidBlock maxValidBlockFunc,
+) error {
+	opts := &bind.CallOpts{From: deployer.Address, Context: ctx}
+
+	var target uint64
+
+	for _, upkeepID := range upkeepIDs {
+		block, err := maxValidBlock(opts, upkeepID)
+		if err != nil {
+			return fmt.Errorf("%w: failed to get upkeep info for %s: %s", ErrContractRead, upkeepID, err.Error())
+		}
+
+		// active upkeeps have no max valid block and can never be withdrawn
+		if block == math.MaxUint32 {
+			return fmt.Errorf("%w: upkeep %s is not cancelled", ErrContractRead, upkeepID)
+		}
+
+		target = max(target, block)
+	}
+
+	current, err := deployer.Client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: failed to get block number: %s", ErrClientInteraction, err.Error())
+	}
+
+	if current >= target {
+		return nil
+	}
+
+	fmt.Printf("waiting %d blocks for upkeep cancellation to complete\n", target-current)
+
+	return waitForBlock(ctx, deployer.Client, target)
+}
+
 type upkeepRegister interface {
 	//nolint:lll
 	BatchRegisterUpkeeps(*bind.TransactOpts, uint8, uint32, uint8, []byte, *big.Int, *big.Int, *big.Int) (*types.Transaction, error)
@@ -761,7 +999,10 @@ func registerNewUpkeeps(
 	count uint8,
 	typ uint8,
 ) ([]*big.Int, error) {
-	var completed uint8 = 0
+	var (
+		completed uint8 = 0
+		fns       []func(*bind.TransactOpts) (*types.Transaction, error)
+	)
 
 	for completed < count {
 		setSize := count - completed
@@ -769,29 +1010,28 @@ func registerNewUpkeeps(
 			setSize = batchSize
 		}
 
-		opts, err := deployer.BuildTxOpts(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("%w: deploy failed: %s", ErrContractCreate, err.Error())
-		}
-
-		trx, err := register.BatchRegisterUpkeeps(
-			opts, setSize, DefaultGasLimit,
-			typ, []byte{0x00},
-			big.NewInt(DefaultRegisterAmount),
-			big.NewInt(DefaultCheckGas),
-			big.NewInt(DefaultPerformGas),
-		)
-		if err != nil {
-			return nil, fmt.Errorf("%w: failed to register upkeeps: %s", ErrContractConnection, err.Error())
-		}
+		fns = append(fns, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			trx, err := register.BatchRegisterUpkeeps(
+				opts, setSize, DefaultGasLimit,
+				typ, []byte{0x00},
+				big.NewInt(DefaultRegisterAmount),
+				big.NewInt(DefaultCheckGas),
+				big.NewInt(DefaultPerformGas),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("%w: failed to register upkeeps: %s", ErrContractConnection, err.Error())
+			}
 
-		if err := deployer.wait(ctx, trx); err != nil {
-			return nil, fmt.Errorf("%w: transaction failed: %s", ErrContractConnection, err.Error())
-		}
+			return trx, nil
+		})
 
 		completed = completed + setSize
 	}
 
+	if err := runContractFuncs(ctx, deployer, fns...); err != nil {
+		return nil, err
+	}
+
 	upkeepOpts := &bind.CallOpts{
 		Context: ctx,
 		From:    deployer.Address,
@@ -817,7 +1057,7 @@ func runContractFunc(
 
 	trx, err := contractFn(opts)
 	if err != nil {
-		return fmt.Errorf("%w: failed to set upkeep intervals: %s", ErrContractConnection, err.Error())
+		return fmt.Errorf("%w: transaction submit failed: %s", ErrContractConnection, err.Error())
 	}
 
 	if err := deployer.wait(ctx, trx); err != nil {
@@ -827,100 +1067,90 @@ func runContractFunc(
 	return nil
 }
 
-//nolint:funlen
-func collectAndWriteStats(
+// runContractFuncs submits all transactions before waiting for any of them such that they can be included in the same
+// or consecutive blocks. Transactions already submitted are waited on when a later one fails to submit. Gas for each
+// transaction is estimated without the earlier unmined transactions, so only transactions that do not depend on each
+// other should be submitted together.
+func runContractFuncs(
 	ctx context.Context,
-	contract verifiableLoadContract,
-	upkeepIDs []*big.Int,
-	block uint64,
-	opts *bind.CallOpts,
+	deployer *Deployer,
+	contractFns ...func(*bind.TransactOpts) (*types.Transaction, error),
 ) error {
-	uStats := &upkeepStats{BlockNumber: block}
-	jobs := make([]util.Job[*upkeepInfo], len(upkeepIDs))
-
-	for idx := range upkeepIDs {
-		jobs[idx] = getUpkeepInfoJob(upkeepIDs[idx], contract, opts)
-	}
-
-	results := util.NewParallel[*upkeepInfo](workerNum).RunWithContext(ctx, jobs)
-	writer := table.NewWriter()
+	trxs := make([]*types.Transaction, 0, len(contractFns))
 
-	writer.SetTitle(fmt.Sprintf("Upkeep Results --> (All STATS BELOW ARE CALCULATED AT BLOCK %d)", block))
-	writer.AppendHeader(headerRowOne, table.RowConfig{AutoMerge: true})
-	writer.AppendHeader(headerRowTwo)
+	var submitErr error
 
-	for _, info := range results {
-		var (
-			maxDelay float64
-			err      error
-		)
+	for _, contractFn := range contractFns {
+		opts, err := deployer.BuildTxOpts(ctx)
+		if err != nil {
+			submitErr = fmt.Errorf("%w: deploy failed: %s", ErrContractCreate, err.Error())
 
-		percentiles := make([]float64, percentilesCalculated)
+			break
+		}
 
-		if len(info.SortedAllDelays) > 0 {
-			percentiles, err = getPercentiles(info.SortedAllDelays, P50, P90, P95, P99)
-			if err != nil {
-				return err
-			}
+		trx, err := contractFn(opts)
+		if err != nil {
+			submitErr = fmt.Errorf("%w: transaction submit failed: %s", ErrContractConnection, err.Error())
 
-			maxDelay = info.SortedAllDelays[len(info.SortedAllDelays)-1]
+			break
 		}
 
-		writer.AppendRow(table.Row{
-			shorten(info.ID.String(), upkeepIDLength),
-			fmt.Sprintf("%d", info.TotalPerforms),
-			fmt.Sprintf("%f", percentiles[0]),
-			fmt.Sprintf("%f", percentiles[1]),
-			fmt.Sprintf("%f", percentiles[2]),
-			fmt.Sprintf("%f", percentiles[3]),
-			fmt.Sprintf("%f", maxDelay),
-			fmt.Sprintf("%d", uint64(info.TotalDelayBlock)),
-			fmt.Sprintf("%f", info.TotalDelayBlock/float64(info.TotalPerforms)),
-		}, table.RowConfig{AutoMerge: true})
+		fmt.Println("transaction submitted: ", trx.Hash(), ", nonce: ", trx.Nonce())
 
-		uStats.AllInfos = append(uStats.AllInfos, info)
-		uStats.TotalPerforms += info.TotalPerforms
-		uStats.TotalDelayBlock += info.TotalDelayBlock
-		uStats.SortedAllDelays = append(uStats.SortedAllDelays, info.SortedAllDelays...)
+		trxs = append(trxs, trx)
 	}
 
-	sort.Float64s(uStats.SortedAllDelays)
+	if err := deployer.WaitAll(ctx, trxs...); err != nil {
+		return fmt.Errorf("%w: transaction failed: %s", ErrContractConnection, err.Error())
+	}
 
-	var (
-		maxDelay float64
-		err      error
-	)
+	return submitErr
+}
 
-	percentiles := make([]float64, percentilesCalculated)
+// collectStats reads the bucketed perform delays of all upkeeps at the block in the call options and summarizes
+// them in a snapshot.
+func collectStats(
+	ctx context.Context,
+	contract verifiableLoadContract,
+	contractAddr, loadType string,
+	upkeepIDs []*big.Int,
+	block uint64,
+	opts *bind.CallOpts,
+	selection statsSelection,
+) (loadstats.Snapshot, error) {
+	jobs := make([]util.Job[*upkeepInfo], len(upkeepIDs))
 
-	if len(uStats.SortedAllDelays) > 0 {
-		percentiles, err = getPercentiles(uStats.SortedAllDelays, P50, P90, P95, P99)
-		if err != nil {
-			return fmt.Errorf("%w: percentile calculation: %s", ErrContractRead, err.Error())
+	for idx := range upkeepIDs {
+		jobs[idx] = getUpkeepInfoJob(upkeepIDs[idx], contract, opts, selection)
+	}
+
+	delays := make(map[string][]float64, len(upkeepIDs))
+	buckets := make(map[string][]loadstats.BucketDelays, len(upkeepIDs))
+
+	for _, info := range util.NewParallel[*upkeepInfo](workerNum).RunWithContext(ctx, jobs) {
+		if info.Err != nil {
+			return loadstats.Snapshot{}, info.Err
 		}
 
-		maxDelay = uStats.SortedAllDelays[len(uStats.SortedAllDelays)-1]
+		delays[info.ID.String()] = info.SortedAllDelays
+		buckets[info.ID.String()] = info.Selected
 	}
 
-	writer.AppendFooter(table.Row{
-		"Total",
-		uStats.TotalPerforms,
-		fmt.Sprintf("%f", percentiles[0]),
-		fmt.Sprintf("%f", percentiles[1]),
-		fmt.Sprintf("%f", percentiles[2]),
-		fmt.Sprintf("%f", percentiles[3]),
-		fmt.Sprintf("%f", maxDelay),
-		fmt.Sprintf("%f", uStats.TotalDelayBlock),
-		fmt.Sprintf("%f", uStats.TotalDelayBlock/float64(uStats.TotalPerforms)),
-	})
+	if err := ctx.Err(); err != nil {
+		return loadstats.Snapshot{}, err
+	}
 
-	writer.SetAutoIndex(true)
-	writer.SetStyle(table.StyleLight)
+	snapshot, err := loadstats.NewSnapshot(contractAddr, loadType, block, delays)
+	if err != nil {
+		return snapshot, err
+	}
 
-	writer.Style().Options.SeparateRows = true
+	snapshot.SinceBlock = selection.sinceBlock
+	snapshot.LastBuckets = selection.lastBuckets
 
-	//nolint:forbidigo
-	fmt.Println(writer.Render())
+	if selection.breakdown {
+		return snapshot, snapshot.AddBreakdown(buckets)
+	}
 
-	return nil
+	return snapshot, nil
 }
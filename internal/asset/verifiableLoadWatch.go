@@ -0,0 +1,244 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
+	"github.com/easterthebunny/automation-cli/internal/util"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	verifiableLogTrigger "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/verifiable_load_log_trigger_upkeep_wrapper"
+	verifiableConditional "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/verifiable_load_upkeep_wrapper"
+)
+
+type watchableLoadContract interface {
+	verifiableLoadContract
+	activeUpkeepLister
+}
+
+// VerifiableLoadWatcher polls a verifiable load contract for upkeep delays. Bucketed delays are cached between polls
+// such that only the buckets that changed since the previous poll are fetched.
+type VerifiableLoadWatcher struct {
+	contract  watchableLoadContract
+	deployer  *Deployer
+	lastBlock uint64
+	upkeeps   map[string]*watchedUpkeep
+}
+
+type watchedUpkeep struct {
+	id      *big.Int
+	counter uint64
+	bucket  uint16
+	buckets map[uint16][]float64
+}
+
+type bucketFetch struct {
+	fetched int
+	err     error
+}
+
+func (d *VerifiableLoadLogTriggerDeployable) Watcher(deployer *Deployer) (*VerifiableLoadWatcher, error) {
+	addr := common.HexToAddress(d.cCfg.Address)
+
+	contract, err := verifiableLogTrigger.NewVerifiableLoadLogTriggerUpkeep(addr, deployer.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a new verifiable load upkeep from address %s: %v", addr, err)
+	}
+
+	return newVerifiableLoadWatcher(contract, deployer), nil
+}
+
+func (d *VerifiableLoadConditionalDeployable) Watcher(deployer *Deployer) (*VerifiableLoadWatcher, error) {
+	addr := common.HexToAddress(d.cCfg.Address)
+
+	contract, err := verifiableConditional.NewVerifiableLoadUpkeep(addr, deployer.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a new verifiable load upkeep from address %s: %v", addr, err)
+	}
+
+	return newVerifiableLoadWatcher(contract, deployer), nil
+}
+
+func newVerifiableLoadWatcher(contract watchableLoadContract, deployer *Deployer) *VerifiableLoadWatcher {
+	return &VerifiableLoadWatcher{
+		contract: contract,
+		deployer: deployer,
+		upkeeps:  make(map[string]*watchedUpkeep),
+	}
+}
+
+// Poll reads the counters and buckets of all active upkeeps at the latest block and fetches the delays of buckets
+// that changed since the previous poll. The cached delays are returned without contract reads when no new block was
+// produced since the previous poll such that a halted chain still shows as stalled upkeeps.
+func (w *VerifiableLoadWatcher) Poll(ctx context.Context) (loadstats.Poll, error) {
+	blockNum, err := w.deployer.Client.BlockNumber(ctx)
+	if err != nil {
+		return loadstats.Poll{}, fmt.Errorf("%w: failed to get block number: %s", ErrContractRead, err.Error())
+	}
+
+	if blockNum == w.lastBlock {
+		return w.cachedPoll(), nil
+	}
+
+	opts := &bind.CallOpts{
+		From:        w.deployer.Address,
+		Context:     ctx,
+		BlockNumber: new(big.Int).SetUint64(blockNum),
+	}
+
+	upkeepIDs, err := w.contract.GetActiveUpkeepIDsDeployedByThisContract(opts, big.NewInt(0), big.NewInt(0))
+	if err != nil {
+		return loadstats.Poll{}, fmt.Errorf("%w: failed to get active upkeep IDs: %s", ErrContractRead, err.Error())
+	}
+
+	active := make(map[string]*watchedUpkeep, len(upkeepIDs))
+	jobs := make([]util.Job[bucketFetch], 0, len(upkeepIDs))
+
+	for _, upkeepID := range upkeepIDs {
+		upkeep, ok := w.upkeeps[upkeepID.String()]
+		if !ok {
+			upkeep = &watchedUpkeep{id: upkeepID}
+		}
+
+		active[upkeepID.String()] = upkeep
+		jobs = append(jobs, w.refreshJob(upkeep, opts))
+	}
+
+	// upkeeps that are no longer active are dropped from the cache
+	w.upkeeps = active
+
+	var fetched int
+
+	for _, result := range util.NewParallel[bucketFetch](workerNum).RunWithContext(ctx, jobs) {
+		if result.err != nil {
+			return loadstats.Poll{}, result.err
+		}
+
+		fetched += result.fetched
+	}
+
+	if err := ctx.Err(); err != nil {
+		return loadstats.Poll{}, err
+	}
+
+	w.lastBlock = blockNum
+
+	poll := w.cachedPoll()
+	poll.BucketsFetched = fetched
+
+	return poll, nil
+}
+
+// cachedPoll returns the cached delays of all active upkeeps at the last polled block.
+func (w *VerifiableLoadWatcher) cachedPoll() loadstats.Poll {
+	poll := loadstats.Poll{
+		BlockNumber: w.lastBlock,
+		Timestamp:   time.Now().UTC(),
+		Upkeeps:     make([]loadstats.Observation, 0, len(w.upkeeps)),
+	}
+
+	for id, upkeep := range w.upkeeps {
+		poll.Upkeeps = append(poll.Upkeeps, loadstats.Observation{ID: id, Delays: upkeep.delays()})
+	}
+
+	return poll
+}
+
+// refreshJob fetches the delays of an upkeep when its perform counter or bucket changed. Buckets before the last
+// fetched bucket are full and are not fetched again. Setting the upkeep interval resets the counter, delays, and
+// buckets on the contract, so the cache is dropped and all buckets are fetched again when the counter or bucket
+// decreases or the last fetched bucket shrinks.
+func (w *VerifiableLoadWatcher) refreshJob(upkeep *watchedUpkeep, opts *bind.CallOpts) util.Job[bucketFetch] {
+	return func(_ context.Context) bucketFetch {
+		counter, err := w.contract.Counters(opts, upkeep.id)
+		if err != nil {
+			return bucketFetch{err: fmt.Errorf("%w: failed to get counter for %s: %s",
+				ErrContractRead, upkeep.id, err.Error())}
+		}
+
+		bucket, err := w.contract.Buckets(opts, upkeep.id)
+		if err != nil {
+			return bucketFetch{err: fmt.Errorf("%w: failed to get current bucket for %s: %s",
+				ErrContractRead, upkeep.id, err.Error())}
+		}
+
+		if upkeep.buckets != nil && counter.Uint64() == upkeep.counter && bucket == upkeep.bucket {
+			return bucketFetch{}
+		}
+
+		if counter.Uint64() < upkeep.counter || bucket < upkeep.bucket {
+			upkeep.buckets = nil
+		}
+
+		first := upkeep.bucket
+		if upkeep.buckets == nil {
+			first = 0
+		}
+
+		fetched, err := w.fetchBuckets(opts, upkeep.id, first, bucket)
+		if err != nil {
+			return bucketFetch{err: err}
+		}
+
+		// a reset followed by enough performs to pass the cached counter is only visible as a shrinking bucket
+		if first > 0 && len(fetched[first]) < len(upkeep.buckets[first]) {
+			upkeep.buckets = nil
+
+			if fetched, err = w.fetchBuckets(opts, upkeep.id, 0, bucket); err != nil {
+				return bucketFetch{err: err}
+			}
+		}
+
+		if upkeep.buckets == nil {
+			upkeep.buckets = make(map[uint16][]float64)
+		}
+
+		for idx, delays := range fetched {
+			upkeep.buckets[idx] = delays
+		}
+
+		upkeep.counter = counter.Uint64()
+		upkeep.bucket = bucket
+
+		return bucketFetch{fetched: len(fetched)}
+	}
+}
+
+func (w *VerifiableLoadWatcher) fetchBuckets(
+	opts *bind.CallOpts,
+	upkeepID *big.Int,
+	first, last uint16,
+) (map[uint16][]float64, error) {
+	fetched := make(map[uint16][]float64, int(last-first)+1)
+
+	for idx := first; idx <= last; idx++ {
+		delays, err := w.contract.GetBucketedDelays(opts, upkeepID, idx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get bucketed delays for %s bucket %d: %s",
+				ErrContractRead, upkeepID, idx, err.Error())
+		}
+
+		fetched[idx] = make([]float64, 0, len(delays))
+
+		for _, delay := range delays {
+			fetched[idx] = append(fetched[idx], float64(delay.Uint64()))
+		}
+	}
+
+	return fetched, nil
+}
+
+// delays returns all cached delays in the order the performs happened.
+func (u *watchedUpkeep) delays() []float64 {
+	var delays []float64
+
+	for idx := uint16(0); idx <= u.bucket; idx++ {
+		delays = append(delays, u.buckets[idx]...)
+	}
+
+	return delays
+}
@@ -3,6 +3,10 @@ package config
 const (
 	EnvironmentConfigFilename    = "config.toml"
 	PrivateKeyConfigFilename     = "keys.json"
+	EncryptedKeystoreFilename    = "keystore.json"
+	TransactionJournalFilename   = "transactions.jsonl"
+	KeystorePasswordEnvVar       = "AUTOMATION_CLI_KEYSTORE_PASSWORD"
+	KeystoreScryptEnvVar         = "AUTOMATION_CLI_KEYSTORE_SCRYPT"
 	DefaultDeployerGasLimit      = uint64(80_000_000)
 	DefaultChainlinkNodePassword = "fj293fbBnlQ!f9vNs~#"
 	DefaultChainlinkNodeLogin    = "notreal@fakeemail.ch"
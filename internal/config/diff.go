@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Change is a single field that differs between two values.
+type Change struct {
+	Path string
+	Old  string
+	New  string
+}
+
+// Diff compares two values of the same type field by field and returns the changed fields with lower case dot
+// separated paths. Lists are compared as a whole.
+func Diff(prefix string, before, after any) []Change {
+	changes := make([]Change, 0)
+
+	diffValues(prefix, reflect.ValueOf(before), reflect.ValueOf(after), &changes)
+
+	return changes
+}
+
+func diffValues(path string, before, after reflect.Value, changes *[]Change) {
+	// values that format themselves such as big integers are compared as a whole
+	for !isStringer(before) && before.Kind() == reflect.Pointer && after.Kind() == reflect.Pointer {
+		if before.IsNil() || after.IsNil() {
+			break
+		}
+
+		before, after = before.Elem(), after.Elem()
+	}
+
+	if !isStringer(before) && before.Kind() == reflect.Struct && before.Type() == after.Type() {
+		for idx := 0; idx < before.NumField(); idx++ {
+			field := before.Type().Field(idx)
+			if !field.IsExported() {
+				continue
+			}
+
+			diffValues(joinPath(path, strings.ToLower(field.Name)), before.Field(idx), after.Field(idx), changes)
+		}
+
+		return
+	}
+
+	beforeStr, afterStr := formatValue(before), formatValue(after)
+
+	if beforeStr != afterStr {
+		*changes = append(*changes, Change{Path: path, Old: beforeStr, New: afterStr})
+	}
+}
+
+func formatValue(value reflect.Value) string {
+	if !value.IsValid() || (value.Kind() == reflect.Pointer && value.IsNil()) {
+		return ""
+	}
+
+	return fmt.Sprint(value.Interface())
+}
+
+func isStringer(value reflect.Value) bool {
+	return value.IsValid() && value.Type().Implements(reflect.TypeOf((*fmt.Stringer)(nil)).Elem())
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
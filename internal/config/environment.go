@@ -1,17 +1,27 @@
 package config
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type Environment struct {
-	Groupname       string `toml:"group-name"`
-	WSURL           string `toml:"ws-url"`
-	HTTPURL         string `toml:"http-url"`
-	ChainID         int64  `toml:"chain-id"`
-	PrivateKeyAlias string `toml:"private-key-alias"`
-	GasLimit        uint64 `toml:"deployer-gas-limit"`
+	Groupname       string   `toml:"group-name"`
+	WSURL           string   `toml:"ws-url"`
+	HTTPURL         string   `toml:"http-url"`
+	WSURLs          []string `toml:"ws-urls"`
+	HTTPURLs        []string `toml:"http-urls"`
+	ChainID         int64    `toml:"chain-id"`
+	PrivateKeyAlias string   `toml:"private-key-alias"`
+	GasLimit        uint64   `toml:"deployer-gas-limit"`
 	Verifier        *Verifier
+	Fees            *FeeConfig
+	Confirmations   *ConfirmationConfig
+
+	// CompletedSteps records the steps of an `up` run that have finished and leave no other trace in the environment.
+	CompletedSteps []string `toml:"completed-steps"`
 
 	LinkToken       *LinkTokenContract
 	LinkETH         *FeedContract
@@ -52,6 +62,16 @@ type AutomationRegistrarV21Contract struct {
 	AutoApprovals []AutomationRegistrarV21AutoApprovalConfig
 }
 
+// ContractVersion returns the registrar version and defaults to v2.1 for environments that were created before the
+// version was recorded.
+func (c AutomationRegistrarV21Contract) ContractVersion() string {
+	if c.Version == "" {
+		return RegistryVersion21
+	}
+
+	return c.Version
+}
+
 type AutomationRegistrarV21AutoApprovalConfig struct {
 	TriggerType           uint8
 	AutoApproveType       uint8
@@ -69,6 +89,14 @@ func GetRegistryMode(mode string) uint8 {
 	}
 }
 
+const (
+	RegistryVersion20 = "v2.0"
+	RegistryVersion21 = "v2.1"
+	RegistryVersion22 = "v2.2"
+)
+
+// AutomationRegistryV21Contract is the registry configuration for all registry versions. Fields that a version does
+// not support are ignored for that version.
 type AutomationRegistryV21Contract struct {
 	Type       ContractType
 	Version    string
@@ -79,6 +107,16 @@ type AutomationRegistryV21Contract struct {
 	OCRNetwork OCR3NetworkConfig
 }
 
+// ContractVersion returns the registry version and defaults to v2.1 for environments that were created before the
+// version was recorded.
+func (c AutomationRegistryV21Contract) ContractVersion() string {
+	if c.Version == "" {
+		return RegistryVersion21
+	}
+
+	return c.Version
+}
+
 type AutomationV21OffchainConfig struct {
 	PerformLockoutWindow int64
 	MinConfirmations     int
@@ -122,6 +160,9 @@ type OCR3NetworkConfig struct {
 	MaxDurationShouldAcceptFinalizedReport  time.Duration `json:"maxDurationShouldAcceptFinalizedReport,omitempty"`
 	MaxDurationShouldTransmitAcceptedReport time.Duration `json:"maxDurationShouldTransmitAcceptedReport,omitempty"`
 	MaxFaultyNodes                          int           `json:"maxFaultyNodes,omitempty"`
+
+	// MaxDurationReport is only used by OCR2 based v2.0 registries
+	MaxDurationReport time.Duration `json:"maxDurationReport,omitempty"`
 }
 
 type VerifiableLoadType string
@@ -137,6 +178,9 @@ type VerifiableLoadContract struct {
 	Address     string
 	UseMercury  bool
 	UseArbitrum bool
+
+	// CancelledUpkeeps records upkeeps that were cancelled by a teardown and still hold LINK to withdraw.
+	CancelledUpkeeps []string `toml:"cancelled-upkeeps"`
 }
 
 type Verifier struct {
@@ -145,6 +189,95 @@ type Verifier struct {
 	NetworkName        string
 }
 
+// HTTPEndpoints returns the primary HTTP endpoint followed by all fallback endpoints without duplicates.
+func (e Environment) HTTPEndpoints() []string {
+	return uniqueEndpoints(append([]string{e.HTTPURL}, e.HTTPURLs...))
+}
+
+// WSEndpoints returns the primary WS endpoint followed by all fallback endpoints without duplicates.
+func (e Environment) WSEndpoints() []string {
+	return uniqueEndpoints(append([]string{e.WSURL}, e.WSURLs...))
+}
+
+func uniqueEndpoints(urls []string) []string {
+	unique := make([]string, 0, len(urls))
+	seen := make(map[string]bool)
+
+	for _, url := range urls {
+		url = strings.TrimSpace(url)
+
+		if url == "" || seen[url] {
+			continue
+		}
+
+		seen[url] = true
+		unique = append(unique, url)
+	}
+
+	return unique
+}
+
+type ConfirmationMode string
+
+const (
+	// DepthConfirmation waits for a number of blocks to be built on top of the block containing the transaction.
+	DepthConfirmation ConfirmationMode = "depth"
+	// SafeConfirmation waits for the block containing the transaction to be at or below the safe block.
+	SafeConfirmation ConfirmationMode = "safe"
+	// FinalizedConfirmation waits for the block containing the transaction to be at or below the finalized block.
+	FinalizedConfirmation ConfirmationMode = "finalized"
+)
+
+// ConfirmationConfig selects how long to wait after a transaction is mined before it is considered complete. The depth
+// mode is used when no mode is set and a depth of zero only waits for the receipt.
+type ConfirmationConfig struct {
+	Mode  ConfirmationMode
+	Depth uint16
+}
+
+// ParseConfirmations reads a confirmation policy from a block count or one of the block tags 'safe' and 'finalized'.
+func ParseConfirmations(value string) (ConfirmationConfig, error) {
+	switch mode := ConfirmationMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case SafeConfirmation, FinalizedConfirmation:
+		return ConfirmationConfig{Mode: mode}, nil
+	default:
+		depth, err := strconv.ParseUint(string(mode), 10, 16)
+		if err != nil {
+			return ConfirmationConfig{}, fmt.Errorf(
+				"%w: confirmations must be a block count, 'safe', or 'finalized': '%s'", ErrInvalidValue, value)
+		}
+
+		return ConfirmationConfig{Mode: DepthConfirmation, Depth: uint16(depth)}, nil
+	}
+}
+
+type FeeStrategy string
+
+const (
+	// LegacyFeeStrategy sends legacy transactions with a suggested gas price.
+	LegacyFeeStrategy FeeStrategy = "legacy"
+	// DynamicFeeStrategy sends EIP-1559 transactions with a suggested tip and a fee cap derived from the base fee.
+	DynamicFeeStrategy FeeStrategy = "1559"
+	// FixedFeeStrategy sends EIP-1559 transactions with the configured fee and tip caps.
+	FixedFeeStrategy FeeStrategy = "fixed"
+)
+
+// FeeConfig selects how transaction fees are priced and how stuck transactions are replaced. EIP-1559 transactions are
+// used when no strategy is set and the chain reports a base fee. All fee values are in wei.
+type FeeConfig struct {
+	Strategy FeeStrategy
+	// MaxFeePerGas is the fee cap for the fixed strategy and an upper bound for the 1559 strategy when not zero
+	MaxFeePerGas uint64
+	// MaxPriorityFeePerGas is the tip cap for the fixed strategy
+	MaxPriorityFeePerGas uint64
+	// InclusionTimeout is how long a transaction may remain unmined before it is resubmitted with higher fees
+	InclusionTimeout time.Duration
+	// BumpPercent is the percentage fees are increased by for each resubmission
+	BumpPercent uint64
+	// BumpCeiling is the highest gas price or fee cap a resubmission will use
+	BumpCeiling uint64
+}
+
 type NodeHostType string
 
 const (
@@ -172,6 +305,8 @@ type NodeConfig struct {
 	ChainID         int64
 	WSURL           string
 	HTTPURL         string
+	WSURLs          []string
+	HTTPURLs        []string
 
 	// Mercury connection configurations
 	MercuryLegacyURL string
@@ -185,3 +320,13 @@ type NodeConfig struct {
 	OnchainPublicKey  string
 	P2PKeyID          string
 }
+
+// HTTPEndpoints returns the primary HTTP endpoint of the node followed by all fallback endpoints without duplicates.
+func (c NodeConfig) HTTPEndpoints() []string {
+	return uniqueEndpoints(append([]string{c.HTTPURL}, c.HTTPURLs...))
+}
+
+// WSEndpoints returns the primary WS endpoint of the node followed by all fallback endpoints without duplicates.
+func (c NodeConfig) WSEndpoints() []string {
+	return uniqueEndpoints(append([]string{c.WSURL}, c.WSURLs...))
+}
@@ -0,0 +1,28 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+func TestParseConfirmations(t *testing.T) {
+	t.Parallel()
+
+	conf, err := config.ParseConfirmations("3")
+
+	require.NoError(t, err)
+	assert.Equal(t, config.ConfirmationConfig{Mode: config.DepthConfirmation, Depth: 3}, conf)
+
+	conf, err = config.ParseConfirmations("Finalized")
+
+	require.NoError(t, err)
+	assert.Equal(t, config.FinalizedConfirmation, conf.Mode)
+
+	_, err = config.ParseConfirmations("latest")
+
+	assert.ErrorIs(t, err, config.ErrInvalidValue)
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/easterthebunny/automation-cli/internal/util"
+)
+
+var (
+	ErrKeystoreDecrypt = fmt.Errorf("failed to decrypt key store")
+	ErrKeystoreEncrypt = fmt.Errorf("failed to encrypt key store")
+)
+
+// EncryptedPrivateKeys is the at-rest form of PrivateKeys. Each key is stored in the geth scrypt keystore format.
+type EncryptedPrivateKeys struct {
+	Keys []EncryptedKey `json:"keys"`
+}
+
+type EncryptedKey struct {
+	Alias  string          `json:"alias"`
+	Crypto json.RawMessage `json:"crypto"`
+}
+
+// WriteEncryptedPrivateKeys encrypts each key with the passphrase at the provided scrypt cost, writes the result to the
+// writer, and closes the writer.
+func WriteEncryptedPrivateKeys(
+	writer io.WriteCloser,
+	keys PrivateKeys,
+	passphrase string,
+	params util.ScryptParams,
+) error {
+	defer writer.Close()
+
+	encrypted := EncryptedPrivateKeys{Keys: make([]EncryptedKey, 0, len(keys.Keys))}
+
+	for _, key := range keys.Keys {
+		privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(key.Value), "0x"))
+		if err != nil {
+			return fmt.Errorf("%w: invalid private key for alias '%s': %s", ErrKeystoreEncrypt, key.Alias, err.Error())
+		}
+
+		data, err := util.FromPrivateKey(privateKey).ToEncryptedJSON(passphrase, params)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrKeystoreEncrypt, err.Error())
+		}
+
+		encrypted.Keys = append(encrypted.Keys, EncryptedKey{
+			Alias:  key.Alias,
+			Crypto: data,
+		})
+	}
+
+	return json.NewEncoder(writer).Encode(encrypted)
+}
+
+// ReadEncryptedPrivateKeysFrom decodes and decrypts all keys from the reader and closes the reader.
+func ReadEncryptedPrivateKeysFrom(reader io.ReadCloser, passphrase string) (PrivateKeys, error) {
+	defer reader.Close()
+
+	var (
+		encrypted EncryptedPrivateKeys
+		keys      PrivateKeys
+	)
+
+	if err := json.NewDecoder(reader).Decode(&encrypted); err != nil {
+		return keys, fmt.Errorf("%w: %s", ErrKeystoreDecrypt, err.Error())
+	}
+
+	for _, encKey := range encrypted.Keys {
+		decrypted, err := keystore.DecryptKey(encKey.Crypto, passphrase)
+		if err != nil {
+			return keys, fmt.Errorf("%w: alias '%s': %s", ErrKeystoreDecrypt, encKey.Alias, err.Error())
+		}
+
+		keys.Keys = append(keys.Keys, Key{
+			Alias:   encKey.Alias,
+			Value:   hexutil.Encode(crypto.FromECDSA(decrypted.PrivateKey))[2:],
+			Address: decrypted.Address.Hex(),
+		})
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+var ErrOverlay = fmt.Errorf("config overlay failure")
+
+const (
+	OverlayFormatJSON = "json"
+	OverlayFormatTOML = "toml"
+)
+
+// registryOverlaySections are the registry sections that can be set from an overlay file.
+var registryOverlaySections = []string{"onchain", "offchain", "ocrnetwork"}
+
+// OverlayRegistryConfig reads a json or toml document with onchain, offchain, and ocrnetwork sections and sets every
+// value it contains on the environment registry. Values not in the document are left unchanged. Durations are written
+// as strings such as "5s" and lists as arrays.
+func OverlayRegistryConfig(env *Environment, reader io.ReadCloser, format string) error {
+	defer reader.Close()
+
+	values := make(map[string]any)
+
+	switch format {
+	case OverlayFormatJSON:
+		decoder := json.NewDecoder(reader)
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&values); err != nil {
+			return fmt.Errorf("%w: %s", ErrOverlay, err.Error())
+		}
+	case OverlayFormatTOML:
+		if err := toml.NewDecoder(reader).Decode(&values); err != nil {
+			return fmt.Errorf("%w: %s", ErrOverlay, err.Error())
+		}
+	default:
+		return fmt.Errorf("%w: unknown format '%s'", ErrOverlay, format)
+	}
+
+	for section := range values {
+		if !isOverlaySection(section) {
+			return fmt.Errorf("%w: unknown section '%s'; expected one of %s",
+				ErrOverlay, section, strings.Join(registryOverlaySections, ", "))
+		}
+	}
+
+	paths := make(map[string]string)
+
+	if err := flattenValues("registry", values, paths); err != nil {
+		return err
+	}
+
+	// set values in a stable order such that errors are reported consistently
+	keys := make([]string, 0, len(paths))
+	for key := range paths {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := SetPath(env, key, paths[key]); err != nil {
+			return fmt.Errorf("%w: %s", ErrOverlay, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func isOverlaySection(section string) bool {
+	for _, name := range registryOverlaySections {
+		if normalizeKey(section) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// flattenValues converts nested documents into dot separated paths with string values as accepted by SetPath.
+func flattenValues(prefix string, values map[string]any, paths map[string]string) error {
+	for key, value := range values {
+		path := prefix + "." + key
+
+		switch typed := value.(type) {
+		case map[string]any:
+			if err := flattenValues(path, typed, paths); err != nil {
+				return err
+			}
+		case []any:
+			encoded, err := json.Marshal(typed)
+			if err != nil {
+				return fmt.Errorf("%w: '%s': %s", ErrOverlay, path, err.Error())
+			}
+
+			paths[path] = string(encoded)
+		default:
+			paths[path] = fmt.Sprint(typed)
+		}
+	}
+
+	return nil
+}
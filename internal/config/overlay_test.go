@@ -0,0 +1,91 @@
+package config_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+func TestOverlayRegistryConfig(t *testing.T) {
+	t.Parallel()
+
+	newEnv := func() config.Environment {
+		return config.Environment{
+			Registry: &config.AutomationRegistryV21Contract{
+				Onchain:  config.AutomationV21OnchainConfig{CheckGasLimit: 1, MaxPerformGas: 2},
+				Offchain: config.AutomationV21OffchainConfig{TargetProbability: "0.999"},
+			},
+		}
+	}
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		env := newEnv()
+		doc := `{
+			"onchain": {"checkGasLimit": 6500000, "registrars": ["0x01", "0x02"]},
+			"ocrNetwork": {"deltaProgress": "10s"}
+		}`
+
+		require.NoError(t, config.OverlayRegistryConfig(&env, io.NopCloser(strings.NewReader(doc)), "json"))
+
+		assert.Equal(t, uint32(6_500_000), env.Registry.Onchain.CheckGasLimit)
+		assert.Equal(t, uint32(2), env.Registry.Onchain.MaxPerformGas)
+		assert.Equal(t, []string{"0x01", "0x02"}, env.Registry.Onchain.Registrars)
+		assert.Equal(t, 10*time.Second, env.Registry.OCRNetwork.DeltaProgress)
+		assert.Equal(t, "0.999", env.Registry.Offchain.TargetProbability)
+	})
+
+	t.Run("toml", func(t *testing.T) {
+		t.Parallel()
+
+		env := newEnv()
+		doc := "[offchain]\ntarget-probability = \"0.95\"\nmax-upkeep-batch-size = 5\n"
+
+		require.NoError(t, config.OverlayRegistryConfig(&env, io.NopCloser(strings.NewReader(doc)), "toml"))
+
+		assert.Equal(t, "0.95", env.Registry.Offchain.TargetProbability)
+		assert.Equal(t, 5, env.Registry.Offchain.MaxUpkeepBatchSize)
+	})
+
+	t.Run("unknown section", func(t *testing.T) {
+		t.Parallel()
+
+		env := newEnv()
+		doc := `{"mode": 1}`
+
+		err := config.OverlayRegistryConfig(&env, io.NopCloser(strings.NewReader(doc)), "json")
+
+		assert.ErrorIs(t, err, config.ErrOverlay)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Parallel()
+
+		env := newEnv()
+		doc := `{"onchain": {"checkGasLimit": -1}}`
+
+		err := config.OverlayRegistryConfig(&env, io.NopCloser(strings.NewReader(doc)), "json")
+
+		assert.ErrorIs(t, err, config.ErrOverlay)
+	})
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	before := config.AutomationV21OnchainConfig{CheckGasLimit: 1, Registrars: []string{"0x01"}}
+	after := config.AutomationV21OnchainConfig{CheckGasLimit: 2, Registrars: []string{"0x01"}}
+
+	assert.Equal(t, []config.Change{
+		{Path: "onchain.checkgaslimit", Old: "1", New: "2"},
+	}, config.Diff("onchain", before, after))
+
+	assert.Empty(t, config.Diff("onchain", before, before))
+}
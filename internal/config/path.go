@@ -0,0 +1,207 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPathNotFound = fmt.Errorf("path not found")
+	ErrPathNotSet   = fmt.Errorf("path not set")
+	ErrInvalidValue = fmt.Errorf("invalid value")
+)
+
+// GetPath returns the value in the environment at the dot separated path. Path segments match struct fields by name
+// or toml key, ignoring case, dashes, and underscores. Numeric segments index into lists such that
+// `participants.2.loglevel` reads the log level of the third participant.
+func GetPath(env Environment, path string) (any, error) {
+	value, err := walkPath(reflect.ValueOf(&env).Elem(), path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return value.Interface(), nil
+}
+
+// SetPath parses the provided string value as the type of the field at the dot separated path and sets it on the
+// environment. Unset sections along the path are created. Lists of values are accepted as JSON arrays or comma
+// separated values.
+func SetPath(env *Environment, path, value string) error {
+	field, err := walkPath(reflect.ValueOf(env).Elem(), path, true)
+	if err != nil {
+		return err
+	}
+
+	if !field.CanSet() {
+		return fmt.Errorf("%w: '%s' cannot be set", ErrPathNotFound, path)
+	}
+
+	if err := setValue(field, value); err != nil {
+		return fmt.Errorf("%w: '%s' for '%s': %s", ErrInvalidValue, value, path, err.Error())
+	}
+
+	return nil
+}
+
+func walkPath(value reflect.Value, path string, create bool) (reflect.Value, error) {
+	if path == "" {
+		return value, nil
+	}
+
+	traversed := make([]string, 0)
+
+	for _, segment := range strings.Split(path, ".") {
+		traversed = append(traversed, segment)
+
+		for value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				if !create {
+					return value, fmt.Errorf("%w: '%s'", ErrPathNotSet, strings.Join(traversed[:len(traversed)-1], "."))
+				}
+
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+
+			value = value.Elem()
+		}
+
+		switch value.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByKey(value, segment)
+			if !ok {
+				return value, fmt.Errorf("%w: '%s'", ErrPathNotFound, strings.Join(traversed, "."))
+			}
+
+			value = field
+		case reflect.Slice, reflect.Array:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= value.Len() {
+				return value, fmt.Errorf("%w: '%s'", ErrPathNotFound, strings.Join(traversed, "."))
+			}
+
+			value = value.Index(idx)
+		default:
+			return value, fmt.Errorf("%w: '%s'", ErrPathNotFound, strings.Join(traversed, "."))
+		}
+	}
+
+	return value, nil
+}
+
+func fieldByKey(value reflect.Value, key string) (reflect.Value, bool) {
+	key = normalizeKey(key)
+
+	for idx := 0; idx < value.NumField(); idx++ {
+		field := value.Type().Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+
+		if normalizeKey(field.Name) == key || (tag != "" && normalizeKey(tag) == key) {
+			return value.Field(idx), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(key))
+}
+
+func setValue(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(int64(duration))
+
+		return nil
+	}
+
+	//nolint:exhaustive
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(parsed)
+	case reflect.Slice:
+		return setSlice(field, value)
+	default:
+		return fmt.Errorf("type %s can only be set by individual fields", field.Type())
+	}
+
+	return nil
+}
+
+func setSlice(field reflect.Value, value string) error {
+	var items []string
+
+	switch trimmed := strings.TrimSpace(value); {
+	case strings.HasPrefix(trimmed, "["):
+		var raw []json.RawMessage
+
+		if err := json.Unmarshal([]byte(trimmed), &raw); err != nil {
+			return err
+		}
+
+		for _, item := range raw {
+			var str string
+
+			// JSON strings are unquoted while numbers and booleans are used as written
+			if err := json.Unmarshal(item, &str); err != nil {
+				str = string(item)
+			}
+
+			items = append(items, str)
+		}
+	case trimmed != "":
+		items = strings.Split(trimmed, ",")
+	}
+
+	slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+
+	for idx, item := range items {
+		if err := setValue(slice.Index(idx), strings.TrimSpace(item)); err != nil {
+			return err
+		}
+	}
+
+	field.Set(slice)
+
+	return nil
+}
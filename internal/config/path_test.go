@@ -0,0 +1,69 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+func TestGetPath(t *testing.T) {
+	t.Parallel()
+
+	env := config.Environment{
+		Groupname: "test",
+		Registry: &config.AutomationRegistryV21Contract{
+			Offchain: config.AutomationV21OffchainConfig{TargetProbability: "0.999"},
+		},
+		Participants: []config.NodeConfig{{LogLevel: "error"}, {LogLevel: "debug"}},
+	}
+
+	value, err := config.GetPath(env, "registry.offchain.targetprobability")
+
+	require.NoError(t, err)
+	assert.Equal(t, "0.999", value)
+
+	value, err = config.GetPath(env, "participants.1.loglevel")
+
+	require.NoError(t, err)
+	assert.Equal(t, "debug", value)
+
+	value, err = config.GetPath(env, "group-name")
+
+	require.NoError(t, err)
+	assert.Equal(t, "test", value)
+
+	_, err = config.GetPath(env, "participants.2.loglevel")
+
+	assert.ErrorIs(t, err, config.ErrPathNotFound)
+
+	_, err = config.GetPath(env, "registrar.address")
+
+	assert.ErrorIs(t, err, config.ErrPathNotSet)
+}
+
+func TestSetPath(t *testing.T) {
+	t.Parallel()
+
+	env := config.Environment{
+		Participants: []config.NodeConfig{{LogLevel: "error"}},
+	}
+
+	require.NoError(t, config.SetPath(&env, "participants.0.loglevel", "debug"))
+	assert.Equal(t, "debug", env.Participants[0].LogLevel)
+
+	require.NoError(t, config.SetPath(&env, "chain-id", "1337"))
+	assert.Equal(t, int64(1337), env.ChainID)
+
+	require.NoError(t, config.SetPath(&env, "registry.ocrnetwork.deltaround", "2s"))
+	assert.Equal(t, 2*time.Second, env.Registry.OCRNetwork.DeltaRound)
+
+	require.NoError(t, config.SetPath(&env, "registry.onchain.registrars", `["0x1", "0x2"]`))
+	assert.Equal(t, []string{"0x1", "0x2"}, env.Registry.Onchain.Registrars)
+
+	assert.ErrorIs(t, config.SetPath(&env, "registry.mode", "300"), config.ErrInvalidValue)
+	assert.ErrorIs(t, config.SetPath(&env, "registry.unknown", "1"), config.ErrPathNotFound)
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"io"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+// Spec is a declarative description of a complete automation environment. Contract sections with an address are
+// connected to as-is while sections without an address are deployed.
+type Spec struct {
+	Groupname       string `toml:"group-name"`
+	ChainID         int64  `toml:"chain-id"`
+	WSURL           string `toml:"ws-url"`
+	HTTPURL         string `toml:"http-url"`
+	PrivateKeyAlias string `toml:"private-key-alias"`
+	GasLimit        uint64 `toml:"deployer-gas-limit"`
+
+	LinkToken   SpecContract `toml:"link-token"`
+	LinkETHFeed SpecFeed     `toml:"link-eth-feed"`
+	FastGasFeed SpecFeed     `toml:"fast-gas-feed"`
+	Registry    SpecRegistry `toml:"registry"`
+	Registrar   SpecContract `toml:"registrar"`
+	Nodes       SpecNodes    `toml:"nodes"`
+	Load        []SpecLoad   `toml:"load"`
+}
+
+type SpecContract struct {
+	Address string `toml:"address"`
+}
+
+type SpecFeed struct {
+	Address string `toml:"address"`
+	Answer  string `toml:"answer"`
+}
+
+type SpecRegistry struct {
+	Address   string `toml:"address"`
+	Version   string `toml:"version"`
+	Mode      string `toml:"mode"`
+	MaxFaulty uint8  `toml:"max-faulty"`
+}
+
+type SpecNodes struct {
+	Image             string   `toml:"image"`
+	BootstrapImage    string   `toml:"bootstrap-image"`
+	Count             uint8    `toml:"count"`
+	LogLevel          string   `toml:"log-level"`
+	BootstrapLogLevel string   `toml:"bootstrap-log-level"`
+	Keys              []string `toml:"keys"`
+	Fund              string   `toml:"fund"`
+}
+
+type SpecLoad struct {
+	Type        VerifiableLoadType `toml:"type"`
+	Address     string             `toml:"address"`
+	UseMercury  bool               `toml:"use-mercury"`
+	UseArbitrum bool               `toml:"use-arbitrum"`
+}
+
+// ReadSpecFrom decodes an environment spec from the reader and closes the reader. Unknown keys are rejected to catch
+// typos before any contract is deployed.
+func ReadSpecFrom(reader io.ReadCloser) (Spec, error) {
+	defer reader.Close()
+
+	var spec Spec
+
+	decoder := toml.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&spec); err != nil {
+		return spec, err
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,241 @@
+package decode
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	registrar "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/automation_registrar_wrapper2_1"
+	iregistry "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/i_keeper_registry_master_wrapper_2_1"
+	link "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/link_token_interface"
+	ethlink "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/mock_ethlink_aggregator_wrapper"
+	gas "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/mock_gas_aggregator_wrapper"
+	verifiableLogTrigger "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/verifiable_load_log_trigger_upkeep_wrapper"
+	verifiableConditional "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/verifiable_load_upkeep_wrapper"
+)
+
+var (
+	ErrUnknownMethod = fmt.Errorf("unknown method")
+	ErrUnknownEvent  = fmt.Errorf("unknown event")
+	ErrDecode        = fmt.Errorf("decode failure")
+)
+
+const (
+	// MethodDeploy is the method name given to contract creation transactions
+	MethodDeploy = "deploy"
+	// MethodTransfer is the method name given to transactions without calldata
+	MethodTransfer = "transfer"
+	// MethodUnknown is the method name given to calldata that does not match a known contract
+	MethodUnknown = "unknown"
+)
+
+// Call is a decoded contract function call.
+type Call struct {
+	Contract string
+	Method   string
+	Args     map[string]any
+}
+
+// Event is a decoded contract event.
+type Event struct {
+	Contract string
+	Name     string
+	Args     map[string]any
+}
+
+type namedABI struct {
+	name   string
+	parsed *abi.ABI
+}
+
+//nolint:gochecknoglobals
+var (
+	loadOnce sync.Once
+	loaded   []namedABI
+	loadErr  error
+)
+
+// knownABIs returns the parsed ABIs of all contracts the cli interacts with. Earlier entries take precedence when
+// selectors match in more than one contract.
+func knownABIs() ([]namedABI, error) {
+	loadOnce.Do(func() {
+		sources := []struct {
+			name string
+			meta *bind.MetaData
+		}{
+			{name: "registry", meta: iregistry.IKeeperRegistryMasterMetaData},
+			{name: "registrar", meta: registrar.AutomationRegistrarMetaData},
+			{name: "verifiable-load-conditional", meta: verifiableConditional.VerifiableLoadUpkeepMetaData},
+			{name: "verifiable-load-log-trigger", meta: verifiableLogTrigger.VerifiableLoadLogTriggerUpkeepMetaData},
+			{name: "link-token", meta: link.LinkTokenMetaData},
+			{name: "link-eth-feed", meta: ethlink.MockETHLINKAggregatorMetaData},
+			{name: "fast-gas-feed", meta: gas.MockGASAggregatorMetaData},
+		}
+
+		for _, source := range sources {
+			parsed, err := source.meta.GetAbi()
+			if err != nil {
+				loadErr = fmt.Errorf("%w: failed to parse %s abi: %s", ErrDecode, source.name, err.Error())
+
+				return
+			}
+
+			loaded = append(loaded, namedABI{name: source.name, parsed: parsed})
+		}
+	})
+
+	return loaded, loadErr
+}
+
+// Method decodes the function call in the calldata using the ABIs of all known contracts.
+func Method(data []byte) (Call, error) {
+	if len(data) < 4 {
+		return Call{}, ErrUnknownMethod
+	}
+
+	abis, err := knownABIs()
+	if err != nil {
+		return Call{}, err
+	}
+
+	for _, known := range abis {
+		method, err := known.parsed.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+
+		args := make(map[string]any)
+
+		if err := method.Inputs.UnpackIntoMap(args, data[4:]); err != nil {
+			return Call{}, fmt.Errorf("%w: %s.%s: %s", ErrDecode, known.name, method.Name, err.Error())
+		}
+
+		return Call{Contract: known.name, Method: method.Name, Args: args}, nil
+	}
+
+	return Call{}, ErrUnknownMethod
+}
+
+// MethodName returns a readable method name for the transaction.
+func MethodName(trx *types.Transaction) string {
+	if trx.To() == nil {
+		return MethodDeploy
+	}
+
+	if len(trx.Data()) == 0 {
+		return MethodTransfer
+	}
+
+	call, err := Method(trx.Data())
+	if err != nil {
+		return MethodUnknown
+	}
+
+	return call.Method
+}
+
+// Log decodes the event in the log using the ABIs of all known contracts.
+func Log(log types.Log) (Event, error) {
+	if len(log.Topics) == 0 {
+		return Event{}, ErrUnknownEvent
+	}
+
+	abis, err := knownABIs()
+	if err != nil {
+		return Event{}, err
+	}
+
+	for _, known := range abis {
+		event, err := known.parsed.EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+
+		args := make(map[string]any)
+
+		if err := event.Inputs.NonIndexed().UnpackIntoMap(args, log.Data); err != nil {
+			return Event{}, fmt.Errorf("%w: %s.%s: %s", ErrDecode, known.name, event.Name, err.Error())
+		}
+
+		var indexed abi.Arguments
+
+		for _, input := range event.Inputs {
+			if input.Indexed {
+				indexed = append(indexed, input)
+			}
+		}
+
+		if err := abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:]); err != nil {
+			return Event{}, fmt.Errorf("%w: %s.%s: %s", ErrDecode, known.name, event.Name, err.Error())
+		}
+
+		return Event{Contract: known.name, Name: event.Name, Args: args}, nil
+	}
+
+	return Event{}, ErrUnknownEvent
+}
+
+// Revert decodes the revert data of a failed call as either a require message or a custom error of a known contract.
+func Revert(data []byte) (string, error) {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason, nil
+	}
+
+	if len(data) < 4 {
+		return "", fmt.Errorf("%w: revert data too short", ErrDecode)
+	}
+
+	abis, err := knownABIs()
+	if err != nil {
+		return "", err
+	}
+
+	for _, known := range abis {
+		for _, abiErr := range known.parsed.Errors {
+			if !bytes.Equal(abiErr.ID[:4], data[:4]) {
+				continue
+			}
+
+			args, err := abiErr.Inputs.Unpack(data[4:])
+			if err != nil || len(args) == 0 {
+				return abiErr.Name, nil //nolint:nilerr
+			}
+
+			return fmt.Sprintf("%s%v", abiErr.Name, args), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: unknown revert 0x%x", ErrDecode, data)
+}
+
+// FormatArgs returns decoded arguments as 'name: value' sorted by name. Byte values are printed as hex.
+func FormatArgs(args map[string]any) []string {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	formatted := make([]string, 0, len(names))
+
+	for _, name := range names {
+		value := args[name]
+
+		switch typed := value.(type) {
+		case []byte:
+			value = fmt.Sprintf("0x%x", typed)
+		case [32]byte:
+			value = fmt.Sprintf("0x%x", typed)
+		}
+
+		formatted = append(formatted, fmt.Sprintf("%s: %v", name, value))
+	}
+
+	return formatted
+}
@@ -6,8 +6,16 @@ type ctxKey int
 
 const (
 	environmentContextKey ctxKey = iota
+	passphraseContextKey
+	commandContextKey
+	dryRunContextKey
+	confirmationsContextKey
 )
 
+// PassphraseFunc provides the passphrase used to unlock the encrypted key store. Confirm is set when the passphrase
+// is used to create the key store such that a mistyped passphrase does not lock the keys.
+type PassphraseFunc func(confirm bool) (string, error)
+
 func ContextWithEnvironment(ctx context.Context, env Environment) context.Context {
 	return context.WithValue(ctx, environmentContextKey, env)
 }
@@ -25,3 +33,55 @@ func EnvironmentFromContext(ctx context.Context) *Environment {
 
 	return &env
 }
+
+func ContextWithPassphrase(ctx context.Context, passphrase PassphraseFunc) context.Context {
+	return context.WithValue(ctx, passphraseContextKey, passphrase)
+}
+
+func PassphraseFromContext(ctx context.Context) PassphraseFunc {
+	val := ctx.Value(passphraseContextKey)
+	if val == nil {
+		return nil
+	}
+
+	passphrase, ok := val.(PassphraseFunc)
+	if !ok {
+		return nil
+	}
+
+	return passphrase
+}
+
+// ContextWithCommand adds the command line that is being run to the context such that records of changes can refer
+// to the command that caused them.
+func ContextWithCommand(ctx context.Context, command string) context.Context {
+	return context.WithValue(ctx, commandContextKey, command)
+}
+
+func CommandFromContext(ctx context.Context) string {
+	command, _ := ctx.Value(commandContextKey).(string)
+
+	return command
+}
+
+// ContextWithDryRun sets whether state changing actions should be simulated and printed instead of applied.
+func ContextWithDryRun(ctx context.Context, dryRun bool) context.Context {
+	return context.WithValue(ctx, dryRunContextKey, dryRun)
+}
+
+func DryRunFromContext(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunContextKey).(bool)
+
+	return dryRun
+}
+
+// ContextWithConfirmations sets the confirmation policy that overrides the environment policy for a single command.
+func ContextWithConfirmations(ctx context.Context, confirmations string) context.Context {
+	return context.WithValue(ctx, confirmationsContextKey, confirmations)
+}
+
+func ConfirmationsFromContext(ctx context.Context) string {
+	confirmations, _ := ctx.Value(confirmationsContextKey).(string)
+
+	return confirmations
+}
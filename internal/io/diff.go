@@ -0,0 +1,64 @@
+package io
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// diffWriter collects written content and prints a unified diff against the file at path on close without changing
+// the file.
+type diffWriter struct {
+	bytes.Buffer
+	path   string
+	name   string
+	output io.Writer
+}
+
+func (w *diffWriter) Close() error {
+	current, err := os.ReadFile(w.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: failed to read %s: %s", ErrFSOpFailure, w.path, err.Error())
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(current)),
+		B:        difflib.SplitLines(w.String()),
+		FromFile: w.name,
+		ToFile:   w.name + " (dry run)",
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+
+	if diff == "" {
+		fmt.Fprintf(w.output, "dry run: no changes to %s\n", w.name)
+
+		return nil
+	}
+
+	fmt.Fprintf(w.output, "dry run: changes to %s not written\n%s", w.name, diff)
+
+	return nil
+}
+
+// noticeWriter discards written content and prints that the file was not written on close.
+type noticeWriter struct {
+	name   string
+	output io.Writer
+}
+
+func (w *noticeWriter) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+func (w *noticeWriter) Close() error {
+	fmt.Fprintf(w.output, "dry run: changes to %s not written\n", w.name)
+
+	return nil
+}
@@ -82,6 +82,8 @@ func (r Root) MustWrite(filename string) io.WriteCloser {
 type Environment struct {
 	Root
 	Name string
+	// DryRun prints changes to environment files as a diff instead of writing them.
+	DryRun bool
 }
 
 // Path returns the absolute filesystem path to the environment directory. If the directory does not exist, it is
@@ -122,7 +124,8 @@ func (e Environment) MustRead(filename string) io.ReadCloser {
 }
 
 // MustWrite opens the provided filename on the root path as writable. If the file does not exist, it is created. Panics
-// on error. Overwrites the file if it exists
+// on error. Overwrites the file if it exists. In dry run mode, the file is left unchanged and the difference between
+// the current file and what was written is printed on close.
 func (e Environment) MustWrite(filename string) io.WriteCloser {
 	rootPath, err := e.Path()
 	if err != nil {
@@ -131,6 +134,10 @@ func (e Environment) MustWrite(filename string) io.WriteCloser {
 
 	filePath := fmt.Sprintf("%s/%s", rootPath, filename)
 
+	if e.DryRun {
+		return &diffWriter{path: filePath, name: filename, output: os.Stdout}
+	}
+
 	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, FileCreateMode)
 	if err != nil {
 		panic(err)
@@ -139,11 +146,63 @@ func (e Environment) MustWrite(filename string) io.WriteCloser {
 	return file
 }
 
+// MustWriteRoot opens the provided filename on the root path shared by all environments as writable. Panics on error.
+// Overwrites the file if it exists. In dry run mode, the file is left unchanged and only a notice is printed on close
+// since shared files such as the key store hold secrets that should not be printed.
+func (e Environment) MustWriteRoot(filename string) io.WriteCloser {
+	if e.DryRun {
+		return &noticeWriter{name: filename, output: os.Stdout}
+	}
+
+	return e.Root.MustWrite(filename)
+}
+
+// RemoveRoot removes the provided filename from the root path shared by all environments. The file is left in place in
+// dry run mode.
+func (e Environment) RemoveRoot(filename string) error {
+	rootPath, err := e.Root.Path()
+	if err != nil {
+		return err
+	}
+
+	if e.DryRun {
+		fmt.Fprintf(os.Stdout, "dry run: %s would be removed\n", filename)
+
+		return nil
+	}
+
+	return os.Remove(fmt.Sprintf("%s/%s", rootPath, filename))
+}
+
 func (e Environment) Delete() error {
 	path, err := e.Path()
 	if err != nil {
 		return err
 	}
 
+	if e.DryRun {
+		fmt.Fprintf(os.Stdout, "dry run: %s would be removed\n", path)
+
+		return nil
+	}
+
 	return os.RemoveAll(path)
 }
+
+// MustAppend opens the provided filename on the environment path for appending. If the file does not exist, it is
+// created. Panics on error.
+func (e Environment) MustAppend(filename string) io.WriteCloser {
+	rootPath, err := e.Path()
+	if err != nil {
+		panic(err)
+	}
+
+	filePath := fmt.Sprintf("%s/%s", rootPath, filename)
+
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, FileCreateMode)
+	if err != nil {
+		panic(err)
+	}
+
+	return file
+}
@@ -0,0 +1,189 @@
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+	cliio "github.com/easterthebunny/automation-cli/internal/io"
+)
+
+var (
+	ErrJournalRead  = fmt.Errorf("journal read failure")
+	ErrJournalWrite = fmt.Errorf("journal write failure")
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusSuccess  Status = "success"
+	StatusFailed   Status = "failed"
+	StatusReplaced Status = "replaced"
+)
+
+// Entry is a single transaction sent from the cli. Entries are stored as one JSON object per line in the environment
+// directory. A transaction is recorded as pending when it is sent and recorded again when the outcome is known.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Hash      string    `json:"hash"`
+	Nonce     uint64    `json:"nonce"`
+	From      string    `json:"from"`
+	To        string    `json:"to,omitempty"`
+	Method    string    `json:"method"`
+	GasUsed   uint64    `json:"gasUsed"`
+	Status    Status    `json:"status"`
+	Block     uint64    `json:"block,omitempty"`
+	Command   string    `json:"command,omitempty"`
+}
+
+//nolint:gochecknoglobals
+var mu sync.Mutex
+
+// Record appends the entry to the journal of the environment in the context. The command in the context is added to
+// the entry if one is not set. Nothing is recorded when the context has no environment.
+func Record(ctx context.Context, entry Entry) error {
+	path := cliio.EnvironmentFromContext(ctx)
+	if path == nil {
+		return nil
+	}
+
+	if entry.Command == "" {
+		entry.Command = cliio.CommandFromContext(ctx)
+	}
+
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrJournalWrite, err.Error())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	writer := path.MustAppend(config.TransactionJournalFilename)
+	defer writer.Close()
+
+	if _, err := writer.Write(append(encoded, '\n')); err != nil {
+		return fmt.Errorf("%w: %s", ErrJournalWrite, err.Error())
+	}
+
+	return nil
+}
+
+// Read returns all journal entries for the environment in the order they were recorded.
+func Read(path cliio.Environment) ([]Entry, error) {
+	reader := path.MustRead(config.TransactionJournalFilename)
+	defer reader.Close()
+
+	return ReadFrom(reader)
+}
+
+func ReadFrom(reader io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var entry Entry
+
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %s", ErrJournalRead, line, err.Error())
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrJournalRead, err.Error())
+	}
+
+	return entries, nil
+}
+
+// Latest returns the most recent entry of each transaction in the order the transactions were first recorded.
+func Latest(entries []Entry) []Entry {
+	index := make(map[string]int, len(entries))
+	latest := make([]Entry, 0, len(entries))
+
+	for _, entry := range entries {
+		key := strings.ToLower(entry.Hash)
+
+		if idx, ok := index[key]; ok {
+			latest[idx] = entry
+
+			continue
+		}
+
+		index[key] = len(latest)
+		latest = append(latest, entry)
+	}
+
+	return latest
+}
+
+// Filter selects journal entries. Empty fields match all entries and addresses, methods, and statuses are compared
+// without case. Command matches any entry that contains the value.
+type Filter struct {
+	Method  string
+	From    string
+	To      string
+	Status  string
+	Command string
+	Limit   int
+}
+
+// Apply returns the entries that match the filter. When a limit is set, only the most recent matching entries are
+// returned.
+func (f Filter) Apply(entries []Entry) []Entry {
+	matched := make([]Entry, 0, len(entries))
+
+	for _, entry := range entries {
+		if f.matches(entry) {
+			matched = append(matched, entry)
+		}
+	}
+
+	if f.Limit > 0 && len(matched) > f.Limit {
+		matched = matched[len(matched)-f.Limit:]
+	}
+
+	return matched
+}
+
+func (f Filter) matches(entry Entry) bool {
+	return matchFold(f.Method, entry.Method) &&
+		matchFold(f.From, entry.From) &&
+		matchFold(f.To, entry.To) &&
+		matchFold(f.Status, string(entry.Status)) &&
+		strings.Contains(entry.Command, f.Command)
+}
+
+func matchFold(filter, value string) bool {
+	return filter == "" || strings.EqualFold(filter, value)
+}
+
+// Find returns the most recent entry for the transaction hash.
+func Find(entries []Entry, hash string) (Entry, bool) {
+	for idx := len(entries) - 1; idx >= 0; idx-- {
+		if strings.EqualFold(entries[idx].Hash, hash) {
+			return entries[idx], true
+		}
+	}
+
+	return Entry{}, false
+}
@@ -0,0 +1,70 @@
+package journal_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/journal"
+)
+
+func TestReadFromAndFilter(t *testing.T) {
+	t.Parallel()
+
+	raw := `{"hash":"0x01","nonce":1,"from":"0xAA","to":"0xBB","method":"registerUpkeep","status":"success","command":"automation-cli up"}
+
+{"hash":"0x02","nonce":2,"from":"0xAA","to":"0xCC","method":"transfer","status":"failed","command":"automation-cli down"}
+{"hash":"0x03","nonce":3,"from":"0xaa","to":"0xBB","method":"registerUpkeep","status":"success","command":"automation-cli up"}
+`
+
+	entries, err := journal.ReadFrom(strings.NewReader(raw))
+
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	matched := journal.Filter{From: "0xaa", Method: "registerupkeep"}.Apply(entries)
+
+	require.Len(t, matched, 2)
+	assert.Equal(t, "0x01", matched[0].Hash)
+
+	matched = journal.Filter{Command: "up", Limit: 1}.Apply(entries)
+
+	require.Len(t, matched, 1)
+	assert.Equal(t, "0x03", matched[0].Hash)
+
+	matched = journal.Filter{Status: string(journal.StatusFailed)}.Apply(entries)
+
+	require.Len(t, matched, 1)
+	assert.Equal(t, uint64(2), matched[0].Nonce)
+
+	entry, ok := journal.Find(entries, "0x02")
+
+	require.True(t, ok)
+	assert.Equal(t, "0xCC", entry.To)
+
+	_, err = journal.ReadFrom(strings.NewReader("not json\n"))
+
+	assert.ErrorIs(t, err, journal.ErrJournalRead)
+}
+
+func TestLatest(t *testing.T) {
+	t.Parallel()
+
+	raw := `{"hash":"0x01","nonce":1,"status":"pending"}
+{"hash":"0x02","nonce":2,"status":"pending"}
+{"hash":"0x01","nonce":1,"status":"success","block":10}
+`
+
+	entries, err := journal.ReadFrom(strings.NewReader(raw))
+
+	require.NoError(t, err)
+
+	latest := journal.Latest(entries)
+
+	require.Len(t, latest, 2)
+	assert.Equal(t, journal.StatusSuccess, latest[0].Status)
+	assert.Equal(t, uint64(10), latest[0].Block)
+	assert.Equal(t, journal.StatusPending, latest[1].Status)
+}
@@ -0,0 +1,218 @@
+package keystore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+	cliio "github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/util"
+)
+
+var (
+	ErrPlaintextKeys      = fmt.Errorf("plaintext key store found: run `automation-cli key migrate` to encrypt it")
+	ErrNoPassphrase       = fmt.Errorf("key store passphrase not available")
+	ErrPassphraseMismatch = fmt.Errorf("key store passphrases do not match")
+	ErrNoEnvironment      = fmt.Errorf("environment not found")
+	ErrNothingToMigrate   = fmt.Errorf("no plaintext keys to migrate")
+	ErrScryptLevel        = fmt.Errorf("invalid key store scrypt level")
+)
+
+// Read unlocks and returns all keys in the encrypted key store for the state directory in the context. An empty set
+// of keys is returned without asking for a passphrase when no key store exists.
+func Read(ctx context.Context) (config.PrivateKeys, error) {
+	var keys config.PrivateKeys
+
+	root, err := rootFromContext(ctx)
+	if err != nil {
+		return keys, err
+	}
+
+	exists, err := nonEmpty(root, config.EncryptedKeystoreFilename)
+	if err != nil {
+		return keys, err
+	}
+
+	if !exists {
+		plaintext, err := nonEmpty(root, config.PrivateKeyConfigFilename)
+		if err != nil {
+			return keys, err
+		}
+
+		if plaintext {
+			return keys, ErrPlaintextKeys
+		}
+
+		return keys, nil
+	}
+
+	passphrase, err := passphraseFromContext(ctx, false)
+	if err != nil {
+		return keys, err
+	}
+
+	return config.ReadEncryptedPrivateKeysFrom(root.MustRead(config.EncryptedKeystoreFilename), passphrase)
+}
+
+// Write encrypts and saves all keys to the key store for the state directory in the context. The passphrase is
+// confirmed when the key store is created. The key store is left unchanged in dry run mode.
+func Write(ctx context.Context, keys config.PrivateKeys) error {
+	env, err := environmentFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	params, err := scryptParams()
+	if err != nil {
+		return err
+	}
+
+	exists, err := nonEmpty(env.Root, config.EncryptedKeystoreFilename)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := passphraseFromContext(ctx, !exists)
+	if err != nil {
+		return err
+	}
+
+	return config.WriteEncryptedPrivateKeys(
+		env.MustWriteRoot(config.EncryptedKeystoreFilename), keys, passphrase, params)
+}
+
+// Migrate encrypts all keys from the plaintext key store, merges them into the encrypted key store, and deletes the
+// plaintext key store. Keys in the plaintext store replace encrypted keys with the same alias. The number of migrated
+// keys is returned. Both key stores are left unchanged in dry run mode.
+func Migrate(ctx context.Context) (int, error) {
+	env, err := environmentFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	root := env.Root
+
+	exists, err := nonEmpty(root, config.PrivateKeyConfigFilename)
+	if err != nil {
+		return 0, err
+	}
+
+	if !exists {
+		return 0, ErrNothingToMigrate
+	}
+
+	plaintext, err := config.ReadPrivateKeysFrom(root.MustRead(config.PrivateKeyConfigFilename))
+	if err != nil {
+		return 0, err
+	}
+
+	var keys config.PrivateKeys
+
+	encrypted, err := nonEmpty(root, config.EncryptedKeystoreFilename)
+	if err != nil {
+		return 0, err
+	}
+
+	if encrypted {
+		passphrase, err := passphraseFromContext(ctx, false)
+		if err != nil {
+			return 0, err
+		}
+
+		if keys, err = config.ReadEncryptedPrivateKeysFrom(
+			root.MustRead(config.EncryptedKeystoreFilename), passphrase); err != nil {
+			return 0, err
+		}
+	}
+
+	keys = merge(keys, plaintext)
+
+	if err := Write(ctx, keys); err != nil {
+		return 0, err
+	}
+
+	if err := env.RemoveRoot(config.PrivateKeyConfigFilename); err != nil {
+		return 0, err
+	}
+
+	return len(plaintext.Keys), nil
+}
+
+func merge(keys config.PrivateKeys, add config.PrivateKeys) config.PrivateKeys {
+MainLoop:
+	for _, newKey := range add.Keys {
+		for idx, key := range keys.Keys {
+			if newKey.Alias == key.Alias {
+				keys.Keys[idx] = newKey
+
+				continue MainLoop
+			}
+		}
+
+		keys.Keys = append(keys.Keys, newKey)
+	}
+
+	return keys
+}
+
+func rootFromContext(ctx context.Context) (cliio.Root, error) {
+	env, err := environmentFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return env.Root, nil
+}
+
+func environmentFromContext(ctx context.Context) (cliio.Environment, error) {
+	env := cliio.EnvironmentFromContext(ctx)
+	if env == nil {
+		return cliio.Environment{}, ErrNoEnvironment
+	}
+
+	return *env, nil
+}
+
+// scryptParams returns the key derivation cost for encrypting keys. Geth's standard level is used unless the light
+// level is selected in the environment, which unlocks faster but makes the passphrase cheaper to brute-force.
+func scryptParams() (util.ScryptParams, error) {
+	switch level := strings.ToLower(strings.TrimSpace(os.Getenv(config.KeystoreScryptEnvVar))); level {
+	case "", "standard":
+		return util.DefaultScryptParams, nil
+	case "light":
+		return util.LightScryptParams, nil
+	default:
+		return util.ScryptParams{}, fmt.Errorf("%w: '%s'; set %s to standard or light",
+			ErrScryptLevel, level, config.KeystoreScryptEnvVar)
+	}
+}
+
+func passphraseFromContext(ctx context.Context, confirm bool) (string, error) {
+	passphrase := cliio.PassphraseFromContext(ctx)
+	if passphrase == nil {
+		return "", ErrNoPassphrase
+	}
+
+	return passphrase(confirm)
+}
+
+func nonEmpty(root cliio.Root, filename string) (bool, error) {
+	path, err := root.Path()
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(fmt.Sprintf("%s/%s", path, filename))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return info.Size() > 0, nil
+}
@@ -0,0 +1,196 @@
+package keystore_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+	cliio "github.com/easterthebunny/automation-cli/internal/io"
+	"github.com/easterthebunny/automation-cli/internal/keystore"
+	"github.com/easterthebunny/automation-cli/internal/util"
+)
+
+func TestMain(m *testing.M) {
+	// the standard scrypt level takes about a second per key
+	_ = os.Setenv(config.KeystoreScryptEnvVar, "light")
+
+	os.Exit(m.Run())
+}
+
+func TestWriteRead(t *testing.T) {
+	t.Parallel()
+
+	root := cliio.Root(t.TempDir())
+	keys := config.PrivateKeys{Keys: []config.Key{newKey(t, "default"), newKey(t, "other")}}
+
+	require.NoError(t, keystore.Write(testContext(root, "secret", nil), keys))
+
+	read, err := keystore.Read(testContext(root, "secret", nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, keys, read)
+}
+
+func TestReadWrongPassphrase(t *testing.T) {
+	t.Parallel()
+
+	root := cliio.Root(t.TempDir())
+	keys := config.PrivateKeys{Keys: []config.Key{newKey(t, "default")}}
+
+	require.NoError(t, keystore.Write(testContext(root, "secret", nil), keys))
+
+	_, err := keystore.Read(testContext(root, "wrong", nil))
+
+	assert.ErrorIs(t, err, config.ErrKeystoreDecrypt)
+}
+
+func TestReadPlaintextKeys(t *testing.T) {
+	t.Parallel()
+
+	root := cliio.Root(t.TempDir())
+	keys := config.PrivateKeys{Keys: []config.Key{newKey(t, "default")}}
+
+	require.NoError(t, config.WritePrivateKeys(root.MustWrite(config.PrivateKeyConfigFilename), keys))
+
+	_, err := keystore.Read(testContext(root, "secret", nil))
+
+	assert.ErrorIs(t, err, keystore.ErrPlaintextKeys)
+}
+
+func TestWriteConfirmsNewKeystore(t *testing.T) {
+	t.Parallel()
+
+	root := cliio.Root(t.TempDir())
+	keys := config.PrivateKeys{Keys: []config.Key{newKey(t, "default")}}
+
+	var confirms []bool
+
+	require.NoError(t, keystore.Write(testContext(root, "secret", &confirms), keys))
+	require.NoError(t, keystore.Write(testContext(root, "secret", &confirms), keys))
+
+	assert.Equal(t, []bool{true, false}, confirms)
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	root := cliio.Root(t.TempDir())
+	first, replaced, replacement, added := newKey(t, "first"), newKey(t, "second"), newKey(t, "second"), newKey(t, "third")
+
+	require.NoError(t, keystore.Write(
+		testContext(root, "secret", nil), config.PrivateKeys{Keys: []config.Key{first, replaced}}))
+	require.NoError(t, config.WritePrivateKeys(
+		root.MustWrite(config.PrivateKeyConfigFilename), config.PrivateKeys{Keys: []config.Key{replacement, added}}))
+
+	migrated, err := keystore.Migrate(testContext(root, "secret", nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, migrated)
+
+	read, err := keystore.Read(testContext(root, "secret", nil))
+
+	require.NoError(t, err)
+	assert.Equal(t, []config.Key{first, replacement, added}, read.Keys)
+
+	path, err := root.Path()
+
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(path, config.PrivateKeyConfigFilename))
+
+	_, err = keystore.Migrate(testContext(root, "secret", nil))
+
+	assert.ErrorIs(t, err, keystore.ErrNothingToMigrate)
+}
+
+func TestWriteScryptLevel(t *testing.T) {
+	root := cliio.Root(t.TempDir())
+	keys := config.PrivateKeys{Keys: []config.Key{newKey(t, "default")}}
+
+	t.Setenv(config.KeystoreScryptEnvVar, "")
+
+	require.NoError(t, keystore.Write(testContext(root, "secret", nil), keys))
+
+	path, err := root.Path()
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(path, config.EncryptedKeystoreFilename))
+	require.NoError(t, err)
+
+	var encrypted config.EncryptedPrivateKeys
+
+	require.NoError(t, json.Unmarshal(data, &encrypted))
+	require.Len(t, encrypted.Keys, 1)
+
+	var stored struct {
+		Crypto struct {
+			KDFParams struct {
+				N int `json:"n"`
+			} `json:"kdfparams"`
+		} `json:"crypto"`
+	}
+
+	require.NoError(t, json.Unmarshal(encrypted.Keys[0].Crypto, &stored))
+	assert.Equal(t, util.DefaultScryptParams.N, stored.Crypto.KDFParams.N)
+
+	t.Setenv(config.KeystoreScryptEnvVar, "insecure")
+
+	assert.ErrorIs(t, keystore.Write(testContext(root, "secret", nil), keys), keystore.ErrScryptLevel)
+}
+
+func TestDryRunLeavesKeyStores(t *testing.T) {
+	t.Parallel()
+
+	root := cliio.Root(t.TempDir())
+	keys := config.PrivateKeys{Keys: []config.Key{newKey(t, "default")}}
+
+	require.NoError(t, config.WritePrivateKeys(root.MustWrite(config.PrivateKeyConfigFilename), keys))
+
+	ctx := cliio.ContextWithEnvironment(testContext(root, "secret", nil),
+		cliio.Environment{Root: root, Name: "test", DryRun: true})
+
+	require.NoError(t, keystore.Write(ctx, keys))
+
+	migrated, err := keystore.Migrate(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, migrated)
+
+	path, err := root.Path()
+
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(path, config.EncryptedKeystoreFilename))
+	assert.FileExists(t, filepath.Join(path, config.PrivateKeyConfigFilename))
+}
+
+func testContext(root cliio.Root, passphrase string, confirms *[]bool) context.Context {
+	ctx := cliio.ContextWithEnvironment(context.Background(), cliio.Environment{Root: root, Name: "test"})
+
+	return cliio.ContextWithPassphrase(ctx, func(confirm bool) (string, error) {
+		if confirms != nil {
+			*confirms = append(*confirms, confirm)
+		}
+
+		return passphrase, nil
+	})
+}
+
+func newKey(t *testing.T, alias string) config.Key {
+	t.Helper()
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	return config.Key{
+		Alias:   alias,
+		Value:   hexutil.Encode(crypto.FromECDSA(privateKey))[2:],
+		Address: crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
+	}
+}
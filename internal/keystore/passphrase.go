@@ -0,0 +1,84 @@
+package keystore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+	cliio "github.com/easterthebunny/automation-cli/internal/io"
+)
+
+// NewPassphraseFunc returns a passphrase source that reads, in order of preference, from the keystore password
+// environment variable, the provided password file, or an interactive prompt on the terminal. The passphrase is only
+// resolved once and a prompted passphrase must be entered twice when it is confirmed.
+func NewPassphraseFunc(passwordFile string, input *os.File, prompt io.Writer) cliio.PassphraseFunc {
+	var (
+		once       sync.Once
+		passphrase string
+		err        error
+	)
+
+	return func(confirm bool) (string, error) {
+		once.Do(func() {
+			passphrase, err = resolvePassphrase(passwordFile, input, prompt, confirm)
+		})
+
+		return passphrase, err
+	}
+}
+
+func resolvePassphrase(passwordFile string, input *os.File, prompt io.Writer, confirm bool) (string, error) {
+	if value, ok := os.LookupEnv(config.KeystorePasswordEnvVar); ok {
+		return value, nil
+	}
+
+	if passwordFile != "" {
+		data, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return "", fmt.Errorf("%w: failed to read password file: %s", ErrNoPassphrase, err.Error())
+		}
+
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	if !term.IsTerminal(int(input.Fd())) {
+		return "", fmt.Errorf(
+			"%w: set %s, provide --keystore-password-file, or run interactively",
+			ErrNoPassphrase, config.KeystorePasswordEnvVar)
+	}
+
+	passphrase, err := promptPassphrase(input, prompt, "Enter key store passphrase: ")
+	if err != nil || !confirm {
+		return passphrase, err
+	}
+
+	confirmation, err := promptPassphrase(input, prompt, "Confirm key store passphrase: ")
+	if err != nil {
+		return "", err
+	}
+
+	if confirmation != passphrase {
+		return "", ErrPassphraseMismatch
+	}
+
+	return passphrase, nil
+}
+
+func promptPassphrase(input *os.File, prompt io.Writer, message string) (string, error) {
+	fmt.Fprint(prompt, message)
+
+	data, err := term.ReadPassword(int(input.Fd()))
+
+	fmt.Fprintln(prompt)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrNoPassphrase, err.Error())
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,132 @@
+package loadstats
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// histogramBins is the maximum number of bins in a delay histogram
+	histogramBins = 10
+	// chartWidth is the maximum number of characters used for bars and time series columns
+	chartWidth = 60
+	// chartHeight is the number of rows in a time series chart
+	chartHeight = 8
+)
+
+// Bin is a histogram bin that counts delays between low and high inclusive.
+type Bin struct {
+	Low   float64
+	High  float64
+	Count int
+}
+
+// Histogram groups block delays into at most the provided number of bins of equal whole block width.
+func Histogram(delays []float64, bins int) []Bin {
+	if len(delays) == 0 || bins <= 0 {
+		return nil
+	}
+
+	low, high := delays[0], delays[0]
+	for _, delay := range delays {
+		low = math.Min(low, delay)
+		high = math.Max(high, delay)
+	}
+
+	width := math.Max(1, math.Ceil((math.Floor(high)-math.Floor(low)+1)/float64(bins)))
+	count := int(math.Floor((math.Floor(high)-math.Floor(low))/width)) + 1
+
+	out := make([]Bin, count)
+	for idx := range out {
+		out[idx].Low = math.Floor(low) + float64(idx)*width
+		out[idx].High = out[idx].Low + width - 1
+	}
+
+	for _, delay := range delays {
+		out[int((math.Floor(delay)-math.Floor(low))/width)].Count++
+	}
+
+	return out
+}
+
+// histogramLines renders each bin as a bar of '#' scaled to the largest bin.
+func histogramLines(bins []Bin, width int) []string {
+	var largest int
+
+	for _, bin := range bins {
+		largest = max(largest, bin.Count)
+	}
+
+	lines := make([]string, 0, len(bins))
+
+	for _, bin := range bins {
+		label := fmt.Sprintf("%g", bin.Low)
+		if bin.High != bin.Low {
+			label = fmt.Sprintf("%g-%g", bin.Low, bin.High)
+		}
+
+		bar := 0
+		if largest > 0 {
+			bar = int(math.Round(float64(bin.Count) / float64(largest) * float64(width)))
+		}
+
+		lines = append(lines, fmt.Sprintf("%12s | %s %d", label, strings.Repeat("#", bar), bin.Count))
+	}
+
+	return lines
+}
+
+// timeSeriesLines renders delays in perform order as a column chart. Performs are grouped into at most width columns
+// where each column shows the largest delay of the group such that short spikes remain visible.
+func timeSeriesLines(delays []float64, width, height int) []string {
+	if len(delays) == 0 {
+		return nil
+	}
+
+	columns := make([]float64, min(width, len(delays)))
+
+	var top float64
+
+	for idx := range columns {
+		start := idx * len(delays) / len(columns)
+		end := (idx + 1) * len(delays) / len(columns)
+
+		for _, delay := range delays[start:end] {
+			columns[idx] = math.Max(columns[idx], delay)
+		}
+
+		top = math.Max(top, columns[idx])
+	}
+
+	lines := make([]string, 0, height+2)
+
+	for row := height; row > 0; row-- {
+		label := ""
+
+		switch row {
+		case height:
+			label = fmt.Sprintf("%g", top)
+		case 1:
+			label = "0"
+		}
+
+		var line strings.Builder
+
+		for _, value := range columns {
+			if top > 0 && math.Round(value/top*float64(height)) >= float64(row) {
+				line.WriteByte('#')
+			} else {
+				line.WriteByte(' ')
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("%12s | %s", label, strings.TrimRight(line.String(), " ")))
+	}
+
+	lines = append(lines,
+		fmt.Sprintf("%12s +%s", "", strings.Repeat("-", len(columns))),
+		fmt.Sprintf("%12s  performs 1 to %d, max delay per column", "", len(delays)))
+
+	return lines
+}
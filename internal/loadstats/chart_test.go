@@ -0,0 +1,56 @@
+package loadstats_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, loadstats.Histogram(nil, 10))
+
+	assert.Equal(t, []loadstats.Bin{
+		{Low: 1, High: 1, Count: 2},
+		{Low: 2, High: 2, Count: 0},
+		{Low: 3, High: 3, Count: 1},
+	}, loadstats.Histogram([]float64{1, 3, 1}, 10))
+
+	assert.Equal(t, []loadstats.Bin{
+		{Low: 0, High: 8, Count: 1},
+		{Low: 9, High: 17, Count: 2},
+		{Low: 18, High: 26, Count: 1},
+	}, loadstats.Histogram([]float64{0, 9, 10, 25}, 3))
+}
+
+func TestSnapshot_AddBreakdown(t *testing.T) {
+	t.Parallel()
+
+	snapshot, err := loadstats.NewSnapshot("0x1", "conditional", 10, map[string][]float64{
+		"1": {1, 2, 8},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, snapshot.AddBreakdown(map[string][]loadstats.BucketDelays{
+		"1": {{Index: 3, Delays: []float64{2, 1}}, {Index: 4, Delays: []float64{8}}},
+	}))
+
+	upkeep := snapshot.Upkeeps[0]
+
+	require.Len(t, upkeep.Buckets, 2)
+	assert.Equal(t, uint16(3), upkeep.Buckets[0].Index)
+	assert.Equal(t, uint64(2), upkeep.Buckets[0].Performs)
+	assert.Equal(t, float64(8), upkeep.Buckets[1].Max)
+	assert.Equal(t, []float64{2, 1, 8}, upkeep.Delays)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, loadstats.Write(&buf, snapshot, loadstats.FormatText))
+	assert.Contains(t, buf.String(), "Delay histogram (blocks):")
+	assert.Contains(t, buf.String(), "performs 1 to 3, max delay per column")
+}
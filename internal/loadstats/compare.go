@@ -0,0 +1,167 @@
+package loadstats
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	MetricPerforms = "performs"
+	MetricP50      = "p50"
+	MetricP90      = "p90"
+	MetricP95      = "p95"
+	MetricP99      = "p99"
+	MetricMax      = "max"
+	MetricAverage  = "average"
+
+	UpkeepMatched = "matched"
+	UpkeepAdded   = "added"
+	UpkeepRemoved = "removed"
+)
+
+// Metrics are the summary values that are compared between snapshots and can be limited by a policy.
+//
+//nolint:gochecknoglobals
+var Metrics = []string{MetricPerforms, MetricP50, MetricP90, MetricP95, MetricP99, MetricMax, MetricAverage}
+
+// MetricChange is the change of a single summary value from the baseline to the candidate. The percent change is not
+// set when the baseline value is zero.
+type MetricChange struct {
+	Metric   string   `json:"metric"`
+	Baseline float64  `json:"baseline"`
+	Current  float64  `json:"current"`
+	Delta    float64  `json:"delta"`
+	Percent  *float64 `json:"percent,omitempty"`
+}
+
+// UpkeepComparison is the comparison of one upkeep between snapshots. Upkeeps that exist in only one snapshot are
+// added or removed and have no changes.
+type UpkeepComparison struct {
+	ID      string         `json:"id"`
+	Status  string         `json:"status"`
+	Changes []MetricChange `json:"changes,omitempty"`
+}
+
+// SnapshotReference identifies a snapshot in a comparison.
+type SnapshotReference struct {
+	Contract    string    `json:"contract"`
+	Type        string    `json:"type"`
+	BlockNumber uint64    `json:"blockNumber"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// Comparison is the change in aggregate and per upkeep delays and perform counts from a baseline snapshot to a
+// candidate snapshot.
+type Comparison struct {
+	Baseline  SnapshotReference  `json:"baseline"`
+	Current   SnapshotReference  `json:"current"`
+	Aggregate []MetricChange     `json:"aggregate"`
+	Upkeeps   []UpkeepComparison `json:"upkeeps"`
+}
+
+// Compare matches upkeeps by id and calculates the change of every metric from the baseline to the current snapshot.
+func Compare(baseline, current Snapshot) Comparison {
+	comparison := Comparison{
+		Baseline:  reference(baseline),
+		Current:   reference(current),
+		Aggregate: compareSummaries(baseline.Total, current.Total),
+	}
+
+	currentByID := make(map[string]Summary, len(current.Upkeeps))
+	for _, upkeep := range current.Upkeeps {
+		currentByID[upkeep.ID] = upkeep.Summary
+	}
+
+	for _, upkeep := range baseline.Upkeeps {
+		summary, ok := currentByID[upkeep.ID]
+		if !ok {
+			comparison.Upkeeps = append(comparison.Upkeeps, UpkeepComparison{ID: upkeep.ID, Status: UpkeepRemoved})
+
+			continue
+		}
+
+		delete(currentByID, upkeep.ID)
+
+		comparison.Upkeeps = append(comparison.Upkeeps, UpkeepComparison{
+			ID:      upkeep.ID,
+			Status:  UpkeepMatched,
+			Changes: compareSummaries(upkeep.Summary, summary),
+		})
+	}
+
+	for id := range currentByID {
+		comparison.Upkeeps = append(comparison.Upkeeps, UpkeepComparison{ID: id, Status: UpkeepAdded})
+	}
+
+	sort.Slice(comparison.Upkeeps, func(i, j int) bool {
+		return lessID(comparison.Upkeeps[i].ID, comparison.Upkeeps[j].ID)
+	})
+
+	return comparison
+}
+
+// Change returns the change for the metric.
+func Change(changes []MetricChange, metric string) (MetricChange, bool) {
+	for _, change := range changes {
+		if change.Metric == metric {
+			return change, true
+		}
+	}
+
+	return MetricChange{}, false
+}
+
+// MetricValue returns the summary value for the metric.
+func MetricValue(summary Summary, metric string) float64 {
+	switch metric {
+	case MetricPerforms:
+		return float64(summary.Performs)
+	case MetricP50:
+		return summary.P50
+	case MetricP90:
+		return summary.P90
+	case MetricP95:
+		return summary.P95
+	case MetricP99:
+		return summary.P99
+	case MetricMax:
+		return summary.Max
+	case MetricAverage:
+		return summary.Average
+	default:
+		return math.NaN()
+	}
+}
+
+func compareSummaries(baseline, current Summary) []MetricChange {
+	changes := make([]MetricChange, 0, len(Metrics))
+
+	for _, metric := range Metrics {
+		change := MetricChange{
+			Metric:   metric,
+			Baseline: MetricValue(baseline, metric),
+			Current:  MetricValue(current, metric),
+		}
+
+		change.Delta = change.Current - change.Baseline
+
+		if change.Baseline != 0 {
+			percent := change.Delta / change.Baseline * 100 //nolint:gomnd
+			change.Percent = &percent
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func reference(snapshot Snapshot) SnapshotReference {
+	return SnapshotReference{
+		Contract:    snapshot.Contract,
+		Type:        snapshot.Type,
+		BlockNumber: snapshot.BlockNumber,
+		Timestamp:   snapshot.Timestamp,
+	}
+}
@@ -0,0 +1,89 @@
+package loadstats_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	baseline := loadstats.Snapshot{
+		Upkeeps: []loadstats.Upkeep{
+			{ID: "1", Summary: loadstats.Summary{Performs: 10, P99: 4, Max: 5}},
+			{ID: "2", Summary: loadstats.Summary{Performs: 10, P99: 2, Max: 2}},
+		},
+		Total: loadstats.Summary{Performs: 20, P99: 4, Max: 5},
+	}
+
+	current := loadstats.Snapshot{
+		Upkeeps: []loadstats.Upkeep{
+			{ID: "1", Summary: loadstats.Summary{Performs: 8, P99: 6, Max: 20}},
+			{ID: "3", Summary: loadstats.Summary{Performs: 10, P99: 1, Max: 1}},
+		},
+		Total: loadstats.Summary{Performs: 18, P99: 6, Max: 20},
+	}
+
+	comparison := loadstats.Compare(baseline, current)
+
+	require.Len(t, comparison.Upkeeps, 3)
+	assert.Equal(t, loadstats.UpkeepMatched, comparison.Upkeeps[0].Status)
+	assert.Equal(t, loadstats.UpkeepRemoved, comparison.Upkeeps[1].Status)
+	assert.Equal(t, loadstats.UpkeepAdded, comparison.Upkeeps[2].Status)
+	assert.Empty(t, comparison.Upkeeps[1].Changes)
+
+	change, ok := loadstats.Change(comparison.Aggregate, loadstats.MetricP99)
+	require.True(t, ok)
+	assert.Equal(t, float64(2), change.Delta)
+	require.NotNil(t, change.Percent)
+	assert.Equal(t, float64(50), *change.Percent)
+
+	change, ok = loadstats.Change(comparison.Aggregate, loadstats.MetricP50)
+	require.True(t, ok)
+	assert.Nil(t, change.Percent, "percent is not set for a zero baseline")
+
+	policy, err := loadstats.ReadPolicy(strings.NewReader(`
+fail-on-removed = true
+
+[aggregate.p99]
+max-increase-percent = 20
+
+[aggregate.performs]
+max-decrease = 5
+
+[upkeep.max]
+max-increase = 10
+`), loadstats.PolicyFormatTOML)
+	require.NoError(t, err)
+
+	violations := policy.Evaluate(comparison)
+
+	require.Len(t, violations, 3)
+	assert.Equal(t, loadstats.Violation{
+		Scope: loadstats.ScopeAggregate, Metric: loadstats.MetricP99,
+		Rule: loadstats.RuleMaxIncreasePercent, Limit: 20, Actual: 50,
+	}, violations[0])
+	assert.Equal(t, loadstats.Violation{
+		Scope: "1", Metric: loadstats.MetricMax, Rule: loadstats.RuleMaxIncrease, Limit: 10, Actual: 15,
+	}, violations[1])
+	assert.Equal(t, loadstats.Violation{Scope: "2", Rule: loadstats.RuleRemoved}, violations[2])
+}
+
+func TestReadPolicy(t *testing.T) {
+	t.Parallel()
+
+	_, err := loadstats.ReadPolicy(
+		strings.NewReader(`{"aggregate":{"p98":{"max-increase":1}}}`), loadstats.PolicyFormatJSON)
+	assert.ErrorIs(t, err, loadstats.ErrPolicy)
+
+	_, err = loadstats.ReadPolicy(strings.NewReader(`{"aggregate":{"p99":{"max-inc":1}}}`), loadstats.PolicyFormatJSON)
+	assert.ErrorIs(t, err, loadstats.ErrPolicy)
+
+	_, err = loadstats.ReadPolicy(strings.NewReader(``), "yaml")
+	assert.ErrorIs(t, err, loadstats.ErrPolicy)
+}
@@ -0,0 +1,359 @@
+package loadstats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatCSV      = "csv"
+	FormatMarkdown = "markdown"
+
+	// upkeepIDLength is the number of upkeep id characters shown in the text table
+	upkeepIDLength = 8
+)
+
+// Formats are the supported output formats.
+//
+//nolint:gochecknoglobals
+var Formats = []string{FormatText, FormatJSON, FormatCSV, FormatMarkdown}
+
+//nolint:gochecknoglobals
+var (
+	headerRowOne = table.Row{
+		"ID", "Total Performs", "Block Delays", "Block Delays", "Block Delays",
+		"Block Delays", "Block Delays", "Block Delays", "Block Delays"}
+	headerRowTwo = table.Row{
+		"", "", "50th", "90th", "95th", "99th", "Max", "Total", "Average"}
+	columns = []string{"id", "performs", "p50", "p90", "p95", "p99", "max", "total", "average"}
+)
+
+// Write renders the snapshot in the provided format.
+func Write(writer io.Writer, snapshot Snapshot, format string) error {
+	switch format {
+	case FormatText:
+		if _, err := fmt.Fprintln(writer, textTable(snapshot).Render()); err != nil {
+			return err
+		}
+
+		return writeBreakdown(writer, snapshot)
+	case FormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(snapshot)
+	case FormatCSV:
+		return writeCSV(writer, snapshot)
+	case FormatMarkdown:
+		_, err := fmt.Fprintln(writer, markdownTable(snapshot).RenderMarkdown())
+
+		return err
+	default:
+		return fmt.Errorf("unknown output format '%s'; supported formats are %v", format, Formats)
+	}
+}
+
+func textTable(snapshot Snapshot) table.Writer {
+	writer := table.NewWriter()
+
+	writer.SetTitle(fmt.Sprintf("Upkeep Results --> (All STATS BELOW ARE CALCULATED AT BLOCK %d%s)",
+		snapshot.BlockNumber, selection(snapshot)))
+	writer.AppendHeader(headerRowOne, table.RowConfig{AutoMerge: true})
+	writer.AppendHeader(headerRowTwo)
+
+	for _, upkeep := range snapshot.Upkeeps {
+		writer.AppendRow(table.Row{
+			shorten(upkeep.ID, upkeepIDLength),
+			fmt.Sprintf("%d", upkeep.Performs),
+			fmt.Sprintf("%f", upkeep.P50),
+			fmt.Sprintf("%f", upkeep.P90),
+			fmt.Sprintf("%f", upkeep.P95),
+			fmt.Sprintf("%f", upkeep.P99),
+			fmt.Sprintf("%f", upkeep.Max),
+			fmt.Sprintf("%d", uint64(upkeep.Total)),
+			fmt.Sprintf("%f", upkeep.Average),
+		}, table.RowConfig{AutoMerge: true})
+	}
+
+	total := snapshot.Total
+
+	writer.AppendFooter(table.Row{
+		"Total",
+		total.Performs,
+		fmt.Sprintf("%f", total.P50),
+		fmt.Sprintf("%f", total.P90),
+		fmt.Sprintf("%f", total.P95),
+		fmt.Sprintf("%f", total.P99),
+		fmt.Sprintf("%f", total.Max),
+		fmt.Sprintf("%f", total.Total),
+		fmt.Sprintf("%f", total.Average),
+	})
+
+	writer.SetAutoIndex(true)
+	writer.SetStyle(table.StyleLight)
+
+	writer.Style().Options.SeparateRows = true
+
+	return writer
+}
+
+// selection describes how the snapshot performs were limited to recent performs.
+func selection(snapshot Snapshot) string {
+	var out string
+
+	if snapshot.SinceBlock > 0 {
+		out += fmt.Sprintf(" FOR PERFORMS SINCE BLOCK %d", snapshot.SinceBlock)
+	}
+
+	if snapshot.LastBuckets > 0 {
+		out += fmt.Sprintf(" IN THE LAST %d BUCKETS", snapshot.LastBuckets)
+	}
+
+	return out
+}
+
+// writeBreakdown writes the per bucket summary, a delay histogram, and a delay time series for each upkeep with a
+// breakdown.
+func writeBreakdown(writer io.Writer, snapshot Snapshot) error {
+	for _, upkeep := range snapshot.Upkeeps {
+		if upkeep.Buckets == nil {
+			continue
+		}
+
+		buckets := table.NewWriter()
+		buckets.SetStyle(table.StyleLight)
+		buckets.SetTitle(fmt.Sprintf("Upkeep %s", upkeep.ID))
+		buckets.AppendHeader(table.Row{"Bucket", "Performs", "50th", "90th", "95th", "99th", "Max", "Average"})
+
+		for _, bucket := range upkeep.Buckets {
+			buckets.AppendRow(table.Row{
+				bucket.Index,
+				bucket.Performs,
+				formatFloat(bucket.P50),
+				formatFloat(bucket.P90),
+				formatFloat(bucket.P95),
+				formatFloat(bucket.P99),
+				formatFloat(bucket.Max),
+				fmt.Sprintf("%f", bucket.Average),
+			})
+		}
+
+		lines := []string{buckets.Render(), "", "Delay histogram (blocks):"}
+		lines = append(lines, histogramLines(Histogram(upkeep.Delays, histogramBins), chartWidth)...)
+		lines = append(lines, "", "Delay time series (blocks):")
+		lines = append(lines, timeSeriesLines(upkeep.Delays, chartWidth, chartHeight)...)
+
+		if _, err := fmt.Fprintf(writer, "\n%s\n", strings.Join(lines, "\n")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func markdownTable(snapshot Snapshot) table.Writer {
+	writer := table.NewWriter()
+
+	header := make(table.Row, len(columns))
+	for idx, column := range columns {
+		header[idx] = column
+	}
+
+	writer.AppendHeader(header)
+
+	for _, record := range records(snapshot) {
+		row := make(table.Row, len(record))
+		for idx, value := range record {
+			row[idx] = value
+		}
+
+		writer.AppendRow(row)
+	}
+
+	return writer
+}
+
+func writeCSV(writer io.Writer, snapshot Snapshot) error {
+	encoder := csv.NewWriter(writer)
+
+	if err := encoder.Write(columns); err != nil {
+		return err
+	}
+
+	if err := encoder.WriteAll(records(snapshot)); err != nil {
+		return err
+	}
+
+	return encoder.Error()
+}
+
+// records returns one record per upkeep followed by the total with full precision values.
+func records(snapshot Snapshot) [][]string {
+	out := make([][]string, 0, len(snapshot.Upkeeps)+1)
+
+	for _, upkeep := range snapshot.Upkeeps {
+		out = append(out, record(upkeep.ID, upkeep.Summary))
+	}
+
+	return append(out, record("total", snapshot.Total))
+}
+
+func record(id string, summary Summary) []string {
+	return []string{
+		id,
+		strconv.FormatUint(summary.Performs, 10),
+		formatFloat(summary.P50),
+		formatFloat(summary.P90),
+		formatFloat(summary.P95),
+		formatFloat(summary.P99),
+		formatFloat(summary.Max),
+		formatFloat(summary.Total),
+		formatFloat(summary.Average),
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func shorten(full string, outLen int) string {
+	if utf8.RuneCountInString(full) < outLen {
+		return full
+	}
+
+	return string([]byte(full)[:outLen])
+}
+
+// WriteComparison renders the comparison and any policy violations as text or json.
+func WriteComparison(writer io.Writer, comparison Comparison, violations []Violation, format string) error {
+	switch format {
+	case FormatText:
+		return writeComparisonText(writer, comparison, violations)
+	case FormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(struct {
+			Comparison
+			Violations []Violation `json:"violations"`
+		}{Comparison: comparison, Violations: append([]Violation{}, violations...)})
+	default:
+		return fmt.Errorf("unknown output format '%s'; supported formats are %v", format, []string{FormatText, FormatJSON})
+	}
+}
+
+func writeComparisonText(writer io.Writer, comparison Comparison, violations []Violation) error {
+	aggregate := table.NewWriter()
+	aggregate.SetStyle(table.StyleLight)
+	aggregate.SetTitle(fmt.Sprintf("Block %d --> Block %d",
+		comparison.Baseline.BlockNumber, comparison.Current.BlockNumber))
+	aggregate.AppendHeader(table.Row{"Aggregate", "Baseline", "Current", "Change"})
+
+	for _, change := range comparison.Aggregate {
+		aggregate.AppendRow(table.Row{
+			change.Metric, formatFloat(change.Baseline), formatFloat(change.Current), formatChange(change),
+		})
+	}
+
+	upkeeps := table.NewWriter()
+	upkeeps.SetStyle(table.StyleLight)
+
+	header := table.Row{"ID", "Status"}
+	for _, metric := range Metrics {
+		header = append(header, metric)
+	}
+
+	upkeeps.AppendHeader(header)
+
+	for _, upkeep := range comparison.Upkeeps {
+		row := table.Row{shorten(upkeep.ID, upkeepIDLength), upkeep.Status}
+
+		for _, metric := range Metrics {
+			if change, ok := Change(upkeep.Changes, metric); ok {
+				row = append(row, formatChange(change))
+			} else {
+				row = append(row, "-")
+			}
+		}
+
+		upkeeps.AppendRow(row)
+	}
+
+	if _, err := fmt.Fprintf(writer, "%s\n%s\n", aggregate.Render(), upkeeps.Render()); err != nil {
+		return err
+	}
+
+	for _, violation := range violations {
+		if _, err := fmt.Fprintf(writer, "VIOLATION: %s\n", violation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatChange returns the signed change of a metric with the percent change when the baseline is not zero.
+func formatChange(change MetricChange) string {
+	if change.Percent == nil {
+		return fmt.Sprintf("%+g", change.Delta)
+	}
+
+	return fmt.Sprintf("%+g (%+.1f%%)", change.Delta, *change.Percent)
+}
+
+// WriteWatch renders the rolling view as a text table.
+func WriteWatch(writer io.Writer, view View) error {
+	output := table.NewWriter()
+	output.SetStyle(table.StyleLight)
+	output.SetTitle(fmt.Sprintf("Block %d at %s (%d buckets fetched)",
+		view.BlockNumber, view.Timestamp.Format(time.TimeOnly), view.BucketsFetched))
+	output.AppendHeader(table.Row{
+		"ID", "Performs", "Rate/min",
+		fmt.Sprintf("Last %d", view.WindowSize), "", "", "", "Status",
+	}, table.RowConfig{AutoMerge: true})
+	output.AppendHeader(table.Row{"", "", "", "50th", "90th", "99th", "Max", ""})
+
+	var stalled int
+
+	for _, upkeep := range view.Upkeeps {
+		status := "ok"
+		if upkeep.Stalled {
+			status = fmt.Sprintf("stalled since %s", upkeep.LastChange.Format(time.TimeOnly))
+			stalled++
+		}
+
+		output.AppendRow(table.Row{
+			shorten(upkeep.ID, upkeepIDLength),
+			upkeep.Performs,
+			fmt.Sprintf("%.2f", upkeep.Rate),
+			formatFloat(upkeep.Window.P50),
+			formatFloat(upkeep.Window.P90),
+			formatFloat(upkeep.Window.P99),
+			formatFloat(upkeep.Window.Max),
+			status,
+		})
+	}
+
+	output.AppendFooter(table.Row{
+		"Total", view.Window.Performs, "",
+		formatFloat(view.Window.P50),
+		formatFloat(view.Window.P90),
+		formatFloat(view.Window.P99),
+		formatFloat(view.Window.Max),
+		fmt.Sprintf("%d stalled", stalled),
+	})
+
+	_, err := fmt.Fprintln(writer, output.Render())
+
+	return err
+}
@@ -0,0 +1,196 @@
+package loadstats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+const (
+	PolicyFormatJSON = "json"
+	PolicyFormatTOML = "toml"
+
+	// ScopeAggregate is the violation scope for the combined stats of all upkeeps
+	ScopeAggregate = "aggregate"
+
+	RuleMaxIncrease        = "max-increase"
+	RuleMaxIncreasePercent = "max-increase-percent"
+	RuleMaxDecrease        = "max-decrease"
+	RuleMaxDecreasePercent = "max-decrease-percent"
+	RuleRemoved            = "removed"
+	RuleMin                = "min"
+	RuleMax                = "max"
+	RuleZeroPerforms       = "zero-performs"
+)
+
+var ErrPolicy = fmt.Errorf("load stats policy failure")
+
+// Threshold limits the change of a metric between snapshots. Increases and decreases are limited in blocks or
+// performs and in percent of the baseline. Unset limits are not checked.
+type Threshold struct {
+	MaxIncrease        *float64 `json:"max-increase"         toml:"max-increase"`
+	MaxIncreasePercent *float64 `json:"max-increase-percent" toml:"max-increase-percent"`
+	MaxDecrease        *float64 `json:"max-decrease"         toml:"max-decrease"`
+	MaxDecreasePercent *float64 `json:"max-decrease-percent" toml:"max-decrease-percent"`
+}
+
+// Policy is the set of thresholds a comparison is checked against. Aggregate thresholds apply to the combined stats
+// of all upkeeps and upkeep thresholds apply to each upkeep found in both snapshots. Thresholds are keyed by metric.
+type Policy struct {
+	Aggregate     map[string]Threshold `json:"aggregate"       toml:"aggregate"`
+	Upkeep        map[string]Threshold `json:"upkeep"          toml:"upkeep"`
+	FailOnRemoved bool                 `json:"fail-on-removed" toml:"fail-on-removed"`
+}
+
+// Violation is a threshold that was exceeded. The scope is the aggregate or an upkeep id.
+type Violation struct {
+	Scope  string  `json:"scope"`
+	Metric string  `json:"metric"`
+	Rule   string  `json:"rule"`
+	Limit  float64 `json:"limit"`
+	Actual float64 `json:"actual"`
+}
+
+func (v Violation) String() string {
+	switch v.Rule {
+	case RuleRemoved:
+		return fmt.Sprintf("%s: upkeep removed", v.Scope)
+	case RuleZeroPerforms:
+		return fmt.Sprintf("%s: upkeep has no performs", v.Scope)
+	case RuleMin:
+		return fmt.Sprintf("%s %s: %g is below min %g", v.Scope, v.Metric, v.Actual, v.Limit)
+	}
+
+	return fmt.Sprintf("%s %s: %s %g exceeded with %g", v.Scope, v.Metric, v.Rule, v.Limit, v.Actual)
+}
+
+// LoadPolicy reads a json or toml policy file where the format is taken from the file extension.
+func LoadPolicy(filePath string) (Policy, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return Policy{}, fmt.Errorf("%w: %s", ErrPolicy, err.Error())
+	}
+
+	defer file.Close()
+
+	return ReadPolicy(file, strings.TrimPrefix(strings.ToLower(filepath.Ext(filePath)), "."))
+}
+
+// ReadPolicy decodes a json or toml policy. Unknown fields and metrics are rejected such that a mistyped threshold
+// does not silently pass.
+func ReadPolicy(reader io.Reader, format string) (Policy, error) {
+	var policy Policy
+
+	if err := decode(reader, format, &policy); err != nil {
+		return policy, err
+	}
+
+	return policy, validateMetrics(policy.Aggregate, policy.Upkeep)
+}
+
+// Evaluate returns every threshold in the policy that the comparison exceeds.
+func (p Policy) Evaluate(comparison Comparison) []Violation {
+	violations := checkThresholds(ScopeAggregate, p.Aggregate, comparison.Aggregate)
+
+	for _, upkeep := range comparison.Upkeeps {
+		switch upkeep.Status {
+		case UpkeepMatched:
+			violations = append(violations, checkThresholds(upkeep.ID, p.Upkeep, upkeep.Changes)...)
+		case UpkeepRemoved:
+			if p.FailOnRemoved {
+				violations = append(violations, Violation{Scope: upkeep.ID, Rule: RuleRemoved})
+			}
+		}
+	}
+
+	return violations
+}
+
+func decode(reader io.Reader, format string, value any) error {
+	switch format {
+	case PolicyFormatJSON:
+		decoder := json.NewDecoder(reader)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(value); err != nil {
+			return fmt.Errorf("%w: %s", ErrPolicy, err.Error())
+		}
+	case PolicyFormatTOML:
+		decoder := toml.NewDecoder(reader)
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(value); err != nil {
+			return fmt.Errorf("%w: %s", ErrPolicy, err.Error())
+		}
+	default:
+		return fmt.Errorf("%w: unknown format '%s'", ErrPolicy, format)
+	}
+
+	return nil
+}
+
+func validateMetrics[T any](sets ...map[string]T) error {
+	for _, set := range sets {
+		for metric := range set {
+			if !isMetric(metric) {
+				return fmt.Errorf("%w: unknown metric '%s'; expected one of %s",
+					ErrPolicy, metric, strings.Join(Metrics, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkThresholds(scope string, thresholds map[string]Threshold, changes []MetricChange) []Violation {
+	metrics := make([]string, 0, len(thresholds))
+	for metric := range thresholds {
+		metrics = append(metrics, metric)
+	}
+
+	sort.Strings(metrics)
+
+	var violations []Violation
+
+	for _, metric := range metrics {
+		change, ok := Change(changes, metric)
+		if !ok {
+			continue
+		}
+
+		threshold := thresholds[metric]
+		add := func(rule string, limit *float64, actual float64) {
+			if limit != nil && actual > *limit {
+				violations = append(violations, Violation{
+					Scope: scope, Metric: metric, Rule: rule, Limit: *limit, Actual: actual,
+				})
+			}
+		}
+
+		add(RuleMaxIncrease, threshold.MaxIncrease, change.Delta)
+		add(RuleMaxDecrease, threshold.MaxDecrease, -change.Delta)
+
+		if change.Percent != nil {
+			add(RuleMaxIncreasePercent, threshold.MaxIncreasePercent, *change.Percent)
+			add(RuleMaxDecreasePercent, threshold.MaxDecreasePercent, -*change.Percent)
+		}
+	}
+
+	return violations
+}
+
+func isMetric(metric string) bool {
+	for _, known := range Metrics {
+		if metric == known {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package loadstats
+
+import "fmt"
+
+var ErrDelaysReset = fmt.Errorf("upkeep delays were reset")
+
+// Selection limits the delays of an upkeep to recent performs. Skip is the number of delays recorded at the since
+// block and bucket size is the number of delays in a full bucket; both are zero when no since block is set. Last
+// buckets limits the selection to the most recent buckets when set.
+type Selection struct {
+	Skip        uint64
+	BucketSize  uint16
+	LastBuckets uint16
+}
+
+// FirstBucket returns the first bucket that holds selected delays given the latest bucket of an upkeep. Buckets
+// before the latest are full, so buckets that were full at the since block hold no selected delays.
+func (s Selection) FirstBucket(latest uint16) uint16 {
+	var first uint16
+
+	if s.LastBuckets > 0 && latest >= s.LastBuckets {
+		first = latest - s.LastBuckets + 1
+	}
+
+	if s.BucketSize > 0 {
+		first = uint16(min(uint64(latest), max(uint64(first), s.Skip/uint64(s.BucketSize))))
+	}
+
+	return first
+}
+
+// Select returns the delays of the buckets from the first selected bucket to the latest bucket without the delays
+// recorded before the since block. Buckets without selected delays are left out. An error is returned when fewer
+// delays exist than were recorded at the since block, which happens when the upkeep interval was set after the since
+// block and the delays were reset.
+func (s Selection) Select(buckets map[uint16][]float64, latest uint16) ([]BucketDelays, error) {
+	if s.BucketSize > 0 {
+		length := uint64(latest)*uint64(s.BucketSize) + uint64(len(buckets[latest]))
+
+		if s.Skip > length {
+			return nil, fmt.Errorf("%w: %d delays were recorded at the since block but only %d exist now",
+				ErrDelaysReset, s.Skip, length)
+		}
+	}
+
+	var selected []BucketDelays
+
+	for idx := int(s.FirstBucket(latest)); idx <= int(latest); idx++ {
+		delays := buckets[uint16(idx)]
+
+		if start := uint64(idx) * uint64(s.BucketSize); s.Skip > start {
+			delays = delays[min(uint64(len(delays)), s.Skip-start):]
+		}
+
+		if len(delays) == 0 {
+			continue
+		}
+
+		selected = append(selected, BucketDelays{Index: uint16(idx), Delays: delays})
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,67 @@
+package loadstats_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
+)
+
+func TestSelection_FirstBucket(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, uint16(0), loadstats.Selection{}.FirstBucket(4))
+	assert.Equal(t, uint16(3), loadstats.Selection{LastBuckets: 2}.FirstBucket(4))
+	assert.Equal(t, uint16(0), loadstats.Selection{LastBuckets: 10}.FirstBucket(4))
+
+	// buckets that were full at the since block are skipped
+	assert.Equal(t, uint16(2), loadstats.Selection{Skip: 25, BucketSize: 10}.FirstBucket(4))
+	assert.Equal(t, uint16(3), loadstats.Selection{Skip: 25, BucketSize: 10, LastBuckets: 2}.FirstBucket(4))
+	assert.Equal(t, uint16(4), loadstats.Selection{Skip: 90, BucketSize: 10}.FirstBucket(4))
+}
+
+func TestSelection_Select(t *testing.T) {
+	t.Parallel()
+
+	buckets := map[uint16][]float64{
+		0: {1, 2, 3},
+		1: {4, 5, 6},
+		2: {7},
+	}
+
+	selected, err := loadstats.Selection{}.Select(buckets, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, []loadstats.BucketDelays{
+		{Index: 0, Delays: []float64{1, 2, 3}},
+		{Index: 1, Delays: []float64{4, 5, 6}},
+		{Index: 2, Delays: []float64{7}},
+	}, selected)
+
+	selected, err = loadstats.Selection{Skip: 4, BucketSize: 3}.Select(buckets, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, []loadstats.BucketDelays{
+		{Index: 1, Delays: []float64{5, 6}},
+		{Index: 2, Delays: []float64{7}},
+	}, selected)
+
+	// no performs since the since block
+	selected, err = loadstats.Selection{Skip: 7, BucketSize: 3}.Select(buckets, 2)
+
+	require.NoError(t, err)
+	assert.Empty(t, selected)
+}
+
+func TestSelection_SelectAfterReset(t *testing.T) {
+	t.Parallel()
+
+	// 150 delays at the since block and only 12 after the interval was set again
+	buckets := map[uint16][]float64{0: {1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}
+
+	_, err := loadstats.Selection{Skip: 150, BucketSize: 100}.Select(buckets, 0)
+
+	assert.ErrorIs(t, err, loadstats.ErrDelaysReset)
+}
@@ -0,0 +1,128 @@
+package loadstats
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Limit bounds the value of a metric. Unset bounds are not checked.
+type Limit struct {
+	Min *float64 `json:"min" toml:"min"`
+	Max *float64 `json:"max" toml:"max"`
+}
+
+// SLO is the set of absolute limits a single snapshot is checked against. Aggregate limits apply to the combined
+// stats of all upkeeps and upkeep limits apply to each upkeep. Limits are keyed by metric.
+type SLO struct {
+	Aggregate      map[string]Limit `json:"aggregate"        toml:"aggregate"`
+	Upkeep         map[string]Limit `json:"upkeep"           toml:"upkeep"`
+	NoZeroPerforms bool             `json:"no-zero-performs" toml:"no-zero-performs"`
+}
+
+// LoadSLO reads a json or toml SLO file where the format is taken from the file extension.
+func LoadSLO(filePath string) (SLO, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return SLO{}, fmt.Errorf("%w: %s", ErrPolicy, err.Error())
+	}
+
+	defer file.Close()
+
+	return ReadSLO(file, strings.TrimPrefix(strings.ToLower(filepath.Ext(filePath)), "."))
+}
+
+// ReadSLO decodes a json or toml SLO. Unknown fields and metrics are rejected.
+func ReadSLO(reader io.Reader, format string) (SLO, error) {
+	var slo SLO
+
+	if err := decode(reader, format, &slo); err != nil {
+		return slo, err
+	}
+
+	return slo, slo.Validate()
+}
+
+// Validate returns an error when a limit is set for an unknown metric.
+func (s SLO) Validate() error {
+	return validateMetrics(s.Aggregate, s.Upkeep)
+}
+
+// Check returns every limit that the snapshot exceeds. Delay limits are not checked for upkeeps without performs
+// since no delays exist to check.
+func (s SLO) Check(snapshot Snapshot) []Violation {
+	violations := checkLimits(ScopeAggregate, s.Aggregate, snapshot.Total)
+
+	for _, upkeep := range snapshot.Upkeeps {
+		limits := s.Upkeep
+
+		if upkeep.Performs == 0 {
+			if s.NoZeroPerforms {
+				violations = append(violations, Violation{Scope: upkeep.ID, Metric: MetricPerforms, Rule: RuleZeroPerforms})
+			}
+
+			limits = map[string]Limit{}
+			if limit, ok := s.Upkeep[MetricPerforms]; ok {
+				limits[MetricPerforms] = limit
+			}
+		}
+
+		violations = append(violations, checkLimits(upkeep.ID, limits, upkeep.Summary)...)
+	}
+
+	return violations
+}
+
+// ViolatingUpkeeps returns the distinct upkeep ids found in the violations in the order they first appear.
+func ViolatingUpkeeps(violations []Violation) []string {
+	seen := make(map[string]struct{})
+	ids := []string{}
+
+	for _, violation := range violations {
+		if violation.Scope == ScopeAggregate {
+			continue
+		}
+
+		if _, ok := seen[violation.Scope]; ok {
+			continue
+		}
+
+		seen[violation.Scope] = struct{}{}
+		ids = append(ids, violation.Scope)
+	}
+
+	return ids
+}
+
+func checkLimits(scope string, limits map[string]Limit, summary Summary) []Violation {
+	metrics := make([]string, 0, len(limits))
+	for metric := range limits {
+		metrics = append(metrics, metric)
+	}
+
+	sort.Strings(metrics)
+
+	var violations []Violation
+
+	for _, metric := range metrics {
+		limit := limits[metric]
+		value := MetricValue(summary, metric)
+
+		if limit.Max != nil && value > *limit.Max {
+			violations = append(violations, Violation{
+				Scope: scope, Metric: metric, Rule: RuleMax, Limit: *limit.Max, Actual: value,
+			})
+		}
+
+		if limit.Min != nil && value < *limit.Min {
+			violations = append(violations, Violation{
+				Scope: scope, Metric: metric, Rule: RuleMin, Limit: *limit.Min, Actual: value,
+			})
+		}
+	}
+
+	return violations
+}
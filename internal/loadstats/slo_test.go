@@ -0,0 +1,58 @@
+package loadstats_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
+)
+
+func TestSLO_Check(t *testing.T) {
+	t.Parallel()
+
+	slo, err := loadstats.ReadSLO(strings.NewReader(`
+no-zero-performs = true
+
+[aggregate.p99]
+max = 4
+
+[upkeep.p99]
+max = 5
+
+[upkeep.performs]
+min = 10
+`), loadstats.PolicyFormatTOML)
+	require.NoError(t, err)
+
+	snapshot := loadstats.Snapshot{
+		Upkeeps: []loadstats.Upkeep{
+			{ID: "1", Summary: loadstats.Summary{Performs: 20, P99: 3}},
+			{ID: "2", Summary: loadstats.Summary{Performs: 20, P99: 7}},
+			{ID: "3", Summary: loadstats.Summary{Performs: 0}},
+		},
+		Total: loadstats.Summary{Performs: 40, P99: 6},
+	}
+
+	violations := slo.Check(snapshot)
+
+	require.Len(t, violations, 4)
+	assert.Equal(t, loadstats.Violation{
+		Scope: loadstats.ScopeAggregate, Metric: loadstats.MetricP99, Rule: loadstats.RuleMax, Limit: 4, Actual: 6,
+	}, violations[0])
+	assert.Equal(t, loadstats.Violation{
+		Scope: "2", Metric: loadstats.MetricP99, Rule: loadstats.RuleMax, Limit: 5, Actual: 7,
+	}, violations[1])
+	assert.Equal(t, loadstats.RuleZeroPerforms, violations[2].Rule)
+	assert.Equal(t, loadstats.Violation{
+		Scope: "3", Metric: loadstats.MetricPerforms, Rule: loadstats.RuleMin, Limit: 10, Actual: 0,
+	}, violations[3])
+
+	assert.Equal(t, []string{"2", "3"}, loadstats.ViolatingUpkeeps(violations))
+	assert.Equal(t, "3 performs: 0 is below min 10", violations[3].String())
+
+	_, err = loadstats.ReadSLO(strings.NewReader(`{"upkeep":{"p98":{"max":1}}}`), loadstats.PolicyFormatJSON)
+	assert.ErrorIs(t, err, loadstats.ErrPolicy)
+}
@@ -0,0 +1,61 @@
+package loadstats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	cliio "github.com/easterthebunny/automation-cli/internal/io"
+)
+
+const (
+	// snapshotPrefix is the filename prefix of snapshots saved in the environment directory
+	snapshotPrefix = "load-stats"
+	// snapshotTimeFormat is a sortable timestamp that is safe to use in filenames
+	snapshotTimeFormat = "20060102T150405Z"
+)
+
+var ErrSnapshot = fmt.Errorf("load stats snapshot failure")
+
+// SnapshotFilename returns the environment directory filename a snapshot is saved as.
+func SnapshotFilename(snapshot Snapshot) string {
+	return fmt.Sprintf("%s-%s-%s.json", snapshotPrefix, snapshot.Type, snapshot.Timestamp.UTC().Format(snapshotTimeFormat))
+}
+
+// Save writes the snapshot as JSON to a timestamped file in the environment directory and returns the filename.
+func Save(path cliio.Environment, snapshot Snapshot) (string, error) {
+	filename := SnapshotFilename(snapshot)
+
+	writer := path.MustWrite(filename)
+	defer writer.Close()
+
+	if err := Write(writer, snapshot, FormatJSON); err != nil {
+		return filename, fmt.Errorf("%w: %s", ErrSnapshot, err.Error())
+	}
+
+	return filename, nil
+}
+
+// Load reads a snapshot from a file path.
+func Load(filePath string) (Snapshot, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("%w: %s", ErrSnapshot, err.Error())
+	}
+
+	defer file.Close()
+
+	return ReadFrom(file)
+}
+
+// ReadFrom decodes a JSON snapshot.
+func ReadFrom(reader io.Reader) (Snapshot, error) {
+	var snapshot Snapshot
+
+	if err := json.NewDecoder(reader).Decode(&snapshot); err != nil {
+		return snapshot, fmt.Errorf("%w: %s", ErrSnapshot, err.Error())
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,171 @@
+package loadstats
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/montanaflynn/stats"
+)
+
+var ErrCalculationFailure = fmt.Errorf("calculation failure")
+
+const (
+	P50 float64 = 50
+	P90 float64 = 90
+	P95 float64 = 95
+	P99 float64 = 99
+)
+
+// Summary is the perform delay summary, in blocks, of one upkeep or of all upkeeps combined.
+type Summary struct {
+	Performs uint64  `json:"performs"`
+	P50      float64 `json:"p50"`
+	P90      float64 `json:"p90"`
+	P95      float64 `json:"p95"`
+	P99      float64 `json:"p99"`
+	Max      float64 `json:"max"`
+	Total    float64 `json:"total"`
+	Average  float64 `json:"average"`
+}
+
+// Upkeep is the delay summary of a single verifiable load upkeep. Buckets and delays are only set when a breakdown
+// was added to the snapshot.
+type Upkeep struct {
+	ID string `json:"id"`
+	Summary
+	Buckets []Bucket  `json:"buckets,omitempty"`
+	Delays  []float64 `json:"-"`
+}
+
+// Bucket is the delay summary of a single contract bucket of an upkeep.
+type Bucket struct {
+	Index uint16 `json:"index"`
+	Summary
+}
+
+// BucketDelays are the perform delays recorded in a single contract bucket in the order the performs happened.
+type BucketDelays struct {
+	Index  uint16
+	Delays []float64
+}
+
+// Snapshot is the delay summary of all upkeeps on a verifiable load contract calculated at a single block. The since
+// block and last buckets are set when the summary is limited to recent performs.
+type Snapshot struct {
+	Contract    string    `json:"contract"`
+	Type        string    `json:"type"`
+	BlockNumber uint64    `json:"blockNumber"`
+	SinceBlock  uint64    `json:"sinceBlock,omitempty"`
+	LastBuckets uint16    `json:"lastBuckets,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+	Upkeeps     []Upkeep  `json:"upkeeps"`
+	Total       Summary   `json:"total"`
+}
+
+// Summarize calculates the delay summary for the provided perform delays. The delays do not need to be sorted.
+func Summarize(delays []float64) (Summary, error) {
+	sorted := make([]float64, len(delays))
+	copy(sorted, delays)
+	sort.Float64s(sorted)
+
+	summary := Summary{Performs: uint64(len(sorted))}
+
+	if len(sorted) == 0 {
+		return summary, nil
+	}
+
+	for _, delay := range sorted {
+		summary.Total += delay
+	}
+
+	percentiles := []*float64{&summary.P50, &summary.P90, &summary.P95, &summary.P99}
+
+	for idx, percentile := range []float64{P50, P90, P95, P99} {
+		value, err := stats.Percentile(sorted, percentile)
+		if err != nil {
+			return summary, fmt.Errorf("%w: %s", ErrCalculationFailure, err.Error())
+		}
+
+		*percentiles[idx] = value
+	}
+
+	summary.Max = sorted[len(sorted)-1]
+	summary.Average = summary.Total / float64(len(sorted))
+
+	return summary, nil
+}
+
+// NewSnapshot summarizes the perform delays of each upkeep and of all upkeeps combined. Upkeeps are ordered by id.
+func NewSnapshot(
+	contract, loadType string,
+	block uint64,
+	delays map[string][]float64,
+) (Snapshot, error) {
+	snapshot := Snapshot{
+		Contract:    contract,
+		Type:        loadType,
+		BlockNumber: block,
+		Timestamp:   time.Now().UTC(),
+		Upkeeps:     make([]Upkeep, 0, len(delays)),
+	}
+
+	var all []float64
+
+	for id, upkeepDelays := range delays {
+		summary, err := Summarize(upkeepDelays)
+		if err != nil {
+			return snapshot, err
+		}
+
+		snapshot.Upkeeps = append(snapshot.Upkeeps, Upkeep{ID: id, Summary: summary})
+		all = append(all, upkeepDelays...)
+	}
+
+	sort.Slice(snapshot.Upkeeps, func(i, j int) bool {
+		return lessID(snapshot.Upkeeps[i].ID, snapshot.Upkeeps[j].ID)
+	})
+
+	total, err := Summarize(all)
+	if err != nil {
+		return snapshot, err
+	}
+
+	snapshot.Total = total
+
+	return snapshot, nil
+}
+
+// AddBreakdown summarizes each bucket of the provided upkeeps and keeps the ordered delays for charts.
+func (s *Snapshot) AddBreakdown(buckets map[string][]BucketDelays) error {
+	for idx := range s.Upkeeps {
+		upkeep := &s.Upkeeps[idx]
+		upkeep.Buckets = make([]Bucket, 0, len(buckets[upkeep.ID]))
+		upkeep.Delays = nil
+
+		for _, bucket := range buckets[upkeep.ID] {
+			summary, err := Summarize(bucket.Delays)
+			if err != nil {
+				return err
+			}
+
+			upkeep.Buckets = append(upkeep.Buckets, Bucket{Index: bucket.Index, Summary: summary})
+			upkeep.Delays = append(upkeep.Delays, bucket.Delays...)
+		}
+	}
+
+	return nil
+}
+
+// lessID orders upkeep ids numerically where both are numbers and lexically otherwise.
+func lessID(left, right string) bool {
+	leftID, leftOK := new(big.Int).SetString(left, 10)
+	rightID, rightOK := new(big.Int).SetString(right, 10)
+
+	if leftOK && rightOK {
+		return leftID.Cmp(rightID) < 0
+	}
+
+	return left < right
+}
@@ -0,0 +1,67 @@
+package loadstats_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
+)
+
+func TestNewSnapshot(t *testing.T) {
+	t.Parallel()
+
+	snapshot, err := loadstats.NewSnapshot("0x01", "conditional", 100, map[string][]float64{
+		"10": {4, 1, 3, 2},
+		"9":  {6},
+		"11": {},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, snapshot.Upkeeps, 3)
+
+	assert.Equal(t, []string{"9", "10", "11"}, []string{
+		snapshot.Upkeeps[0].ID, snapshot.Upkeeps[1].ID, snapshot.Upkeeps[2].ID})
+
+	assert.Equal(t, uint64(4), snapshot.Upkeeps[1].Performs)
+	assert.Equal(t, float64(4), snapshot.Upkeeps[1].Max)
+	assert.Equal(t, float64(10), snapshot.Upkeeps[1].Total)
+	assert.Equal(t, 2.5, snapshot.Upkeeps[1].Average)
+	assert.Equal(t, loadstats.Summary{}, snapshot.Upkeeps[2].Summary)
+
+	assert.Equal(t, uint64(5), snapshot.Total.Performs)
+	assert.Equal(t, float64(6), snapshot.Total.Max)
+	assert.Equal(t, float64(16), snapshot.Total.Total)
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	snapshot, err := loadstats.NewSnapshot("0x01", "log-trigger", 100, map[string][]float64{"1": {1, 2}})
+	require.NoError(t, err)
+
+	var csvOut bytes.Buffer
+
+	require.NoError(t, loadstats.Write(&csvOut, snapshot, loadstats.FormatCSV))
+	assert.Equal(t, strings.Join([]string{
+		"id,performs,p50,p90,p95,p99,max,total,average",
+		"1,2,1,1.5,1.5,1.5,2,3,1.5",
+		"total,2,1,1.5,1.5,1.5,2,3,1.5",
+		"",
+	}, "\n"), csvOut.String())
+
+	var jsonOut bytes.Buffer
+
+	require.NoError(t, loadstats.Write(&jsonOut, snapshot, loadstats.FormatJSON))
+
+	decoded, err := loadstats.ReadFrom(&jsonOut)
+
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Upkeeps, decoded.Upkeeps)
+	assert.Equal(t, snapshot.Total, decoded.Total)
+
+	assert.Error(t, loadstats.Write(&jsonOut, snapshot, "yaml"))
+}
@@ -0,0 +1,159 @@
+package loadstats
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// rateSamples is the number of polls over which the perform rate is calculated
+	rateSamples = 10
+)
+
+// Observation is the complete list of perform delays of an upkeep in the order the performs happened.
+type Observation struct {
+	ID     string
+	Delays []float64
+}
+
+// Poll is the state of all active upkeeps on a verifiable load contract read at a single block.
+type Poll struct {
+	BlockNumber    uint64
+	Timestamp      time.Time
+	BucketsFetched int
+	Upkeeps        []Observation
+}
+
+// UpkeepStatus is the rolling view of a single upkeep. The perform rate is in performs per minute, the window
+// summary covers only the most recent performs, and the last change is the poll time at which the perform count last
+// changed or the upkeep was first seen.
+type UpkeepStatus struct {
+	ID         string
+	Performs   uint64
+	Rate       float64
+	Window     Summary
+	LastChange time.Time
+	Stalled    bool
+}
+
+// View is the rolling view of all upkeeps after a poll.
+type View struct {
+	BlockNumber    uint64
+	Timestamp      time.Time
+	BucketsFetched int
+	WindowSize     int
+	Upkeeps        []UpkeepStatus
+	Window         Summary
+}
+
+// Tracker keeps the perform history of upkeeps between polls.
+type Tracker struct {
+	window     int
+	stallAfter time.Duration
+	upkeeps    map[string]*tracked
+}
+
+type tracked struct {
+	samples    []sample
+	lastChange time.Time
+}
+
+type sample struct {
+	at       time.Time
+	performs uint64
+}
+
+// NewTracker creates a tracker that summarizes the last window performs of each upkeep and reports an upkeep as
+// stalled when it has not performed for the stall duration.
+func NewTracker(window int, stallAfter time.Duration) *Tracker {
+	return &Tracker{
+		window:     window,
+		stallAfter: stallAfter,
+		upkeeps:    make(map[string]*tracked),
+	}
+}
+
+// Update adds the poll to the upkeep history and returns the rolling view. Upkeeps that are no longer active are
+// dropped from the history.
+func (t *Tracker) Update(poll Poll) (View, error) {
+	view := View{
+		BlockNumber:    poll.BlockNumber,
+		Timestamp:      poll.Timestamp,
+		BucketsFetched: poll.BucketsFetched,
+		WindowSize:     t.window,
+		Upkeeps:        make([]UpkeepStatus, 0, len(poll.Upkeeps)),
+	}
+
+	active := make(map[string]struct{}, len(poll.Upkeeps))
+
+	var all []float64
+
+	for _, observation := range poll.Upkeeps {
+		active[observation.ID] = struct{}{}
+
+		recent := observation.Delays
+		if t.window > 0 && len(recent) > t.window {
+			recent = recent[len(recent)-t.window:]
+		}
+
+		summary, err := Summarize(recent)
+		if err != nil {
+			return view, err
+		}
+
+		all = append(all, recent...)
+		performs := uint64(len(observation.Delays))
+		view.Upkeeps = append(view.Upkeeps, t.status(observation.ID, performs, poll.Timestamp, summary))
+	}
+
+	for id := range t.upkeeps {
+		if _, ok := active[id]; !ok {
+			delete(t.upkeeps, id)
+		}
+	}
+
+	sort.Slice(view.Upkeeps, func(i, j int) bool {
+		return lessID(view.Upkeeps[i].ID, view.Upkeeps[j].ID)
+	})
+
+	window, err := Summarize(all)
+	if err != nil {
+		return view, err
+	}
+
+	view.Window = window
+
+	return view, nil
+}
+
+func (t *Tracker) status(id string, performs uint64, now time.Time, window Summary) UpkeepStatus {
+	history, ok := t.upkeeps[id]
+	if !ok {
+		history = &tracked{lastChange: now}
+		t.upkeeps[id] = history
+	}
+
+	if count := len(history.samples); count > 0 && history.samples[count-1].performs != performs {
+		history.lastChange = now
+	}
+
+	history.samples = append(history.samples, sample{at: now, performs: performs})
+	if len(history.samples) > rateSamples {
+		history.samples = history.samples[len(history.samples)-rateSamples:]
+	}
+
+	status := UpkeepStatus{
+		ID:         id,
+		Performs:   performs,
+		Window:     window,
+		LastChange: history.lastChange,
+		Stalled:    now.Sub(history.lastChange) >= t.stallAfter,
+	}
+
+	first := history.samples[0]
+	if elapsed := now.Sub(first.at); elapsed > 0 && performs >= first.performs {
+		status.Rate = float64(performs-first.performs) / elapsed.Minutes()
+	}
+
+	return status
+}
@@ -0,0 +1,46 @@
+package loadstats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/loadstats"
+)
+
+func TestTracker_Update(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := loadstats.NewTracker(2, 2*time.Minute)
+
+	_, err := tracker.Update(loadstats.Poll{
+		Timestamp: start,
+		Upkeeps: []loadstats.Observation{
+			{ID: "1", Delays: []float64{9, 1}},
+			{ID: "2", Delays: []float64{1}},
+		},
+	})
+	require.NoError(t, err)
+
+	view, err := tracker.Update(loadstats.Poll{
+		Timestamp: start.Add(3 * time.Minute),
+		Upkeeps: []loadstats.Observation{
+			{ID: "1", Delays: []float64{9, 1, 2, 3, 4, 5, 6, 7}},
+			{ID: "2", Delays: []float64{1}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, view.Upkeeps, 2)
+
+	assert.Equal(t, uint64(8), view.Upkeeps[0].Performs)
+	assert.Equal(t, float64(2), view.Upkeeps[0].Rate)
+	assert.Equal(t, float64(7), view.Upkeeps[0].Window.Max, "window only includes the most recent performs")
+	assert.False(t, view.Upkeeps[0].Stalled)
+
+	assert.True(t, view.Upkeeps[1].Stalled)
+	assert.Equal(t, start, view.Upkeeps[1].LastChange)
+	assert.Equal(t, uint64(3), view.Window.Performs)
+}
@@ -24,6 +24,32 @@ chainID = %d`
 ListenAddresses = ["0.0.0.0:%s"]`
 )
 
+// NewBootstrapConfig returns a docker hosted bootstrap node configuration for the provided environment.
+func NewBootstrapConfig(env config.Environment, image, logLevel string) *config.NodeConfig {
+	return &config.NodeConfig{
+		HostType:            config.Docker,
+		Name:                "bootstrap",
+		Image:               image,
+		LogLevel:            logLevel,
+		ListenPort:          5688,
+		LoginName:           config.DefaultChainlinkNodeLogin,
+		LoginPassword:       config.DefaultChainlinkNodePassword,
+		IsBootstrap:         true,
+		BootstrapListenPort: 8000,
+
+		ChainID:  env.ChainID,
+		WSURL:    env.WSURL,
+		HTTPURL:  env.HTTPURL,
+		WSURLs:   env.WSURLs,
+		HTTPURLs: env.HTTPURLs,
+
+		MercuryLegacyURL: config.DefaultMercuryLegacyURL,
+		MercuryURL:       config.DefaultMercuryURL,
+		MercuryID:        config.DefaultMercuryID,
+		MercuryKey:       config.DefaultMercuryKey,
+	}
+}
+
 // CreateBootstrapNode starts the ocr2 bootstrap node with the given contract
 // address, returns the tcp address of the node.
 func CreateBootstrapNode(
@@ -33,6 +59,10 @@ func CreateBootstrapNode(
 	path string,
 	reset bool,
 ) error {
+	if dryRun(ctx, "create bootstrap node %s-%s", groupname, conf.Name) {
+		return nil
+	}
+
 	node, err := buildChainlinkNode(
 		ctx, io.Discard, conf,
 		dockerNodeConfig{
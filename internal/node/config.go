@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/easterthebunny/automation-cli/internal/config"
 )
@@ -27,8 +28,9 @@ Enabled = true
 TurnLookBack = 0
 [[EVM]]
 ChainID = '%d'
-[[EVM.Nodes]]
-Name = 'node-0'
+`
+	evmNodeTOML = `[[EVM.Nodes]]
+Name = 'node-%d'
 WSURL = '%s'
 HTTPURL = '%s'
 `
@@ -64,8 +66,28 @@ contractVersion = "%s"
 mercuryCredentialName = "%s"`
 )
 
+// NodeTOML returns the node configuration with an EVM node for each configured endpoint such that the node fails over
+// between them. When the number of WS and HTTP endpoints differs, endpoints of the shorter list are repeated.
 func NodeTOML(conf config.NodeConfig) string {
-	return fmt.Sprintf(nodeTOML, conf.LogLevel, conf.ChainID, conf.WSURL, conf.HTTPURL)
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf(nodeTOML, conf.LogLevel, conf.ChainID))
+
+	wsURLs, httpURLs := conf.WSEndpoints(), conf.HTTPEndpoints()
+
+	for idx := 0; idx < max(len(wsURLs), len(httpURLs), 1); idx++ {
+		builder.WriteString(fmt.Sprintf(evmNodeTOML, idx, endpointAt(wsURLs, idx), endpointAt(httpURLs, idx)))
+	}
+
+	return builder.String()
+}
+
+func endpointAt(urls []string, idx int) string {
+	if len(urls) == 0 {
+		return ""
+	}
+
+	return urls[idx%len(urls)]
 }
 
 func SecretTOML(conf config.NodeConfig) string {
@@ -110,12 +110,30 @@ func removeChainlinkNode(
 		Force:         true,
 	}
 
-	if err := node.client.ContainerRemove(ctx, node.chainlink.id, options); err != nil {
-		return fmt.Errorf("failed to remove existing container: %w", err)
+	// containers that no longer exist are skipped such that a partial removal can be repeated
+	for _, id := range []string{node.chainlink.id, node.postgres.id} {
+		if id == "" {
+			continue
+		}
+
+		if err := node.client.ContainerRemove(ctx, id, options); err != nil {
+			return fmt.Errorf("failed to remove existing container: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func removeNetwork(ctx context.Context, group string) error {
+	dockerClient, err := client.NewClientWithOpts(client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("failed to create docker client from env: %w", err)
 	}
 
-	if err := node.client.ContainerRemove(ctx, node.postgres.id, options); err != nil {
-		return fmt.Errorf("failed to remove existing container: %w", err)
+	defer dockerClient.Close()
+
+	if err := dockerClient.NetworkRemove(ctx, fmt.Sprintf("%s-local", group)); err != nil && !client.IsErrNotFound(err) {
+		return fmt.Errorf("failed to remove network: %w", err)
 	}
 
 	return nil
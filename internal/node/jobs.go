@@ -0,0 +1,163 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/easterthebunny/automation-cli/internal/config"
+)
+
+const (
+	jobsEndpoint = "/v2/jobs"
+
+	jobTypeBootstrap = "bootstrap"
+	jobTypeOCR2      = "offchainreporting2"
+)
+
+type jobSpecPresenter struct {
+	ContractID string `json:"contractID"`
+}
+
+type JobPresenter struct {
+	JAID
+	Attributes struct {
+		Name          string            `json:"name"`
+		Type          string            `json:"type"`
+		BootstrapSpec *jobSpecPresenter `json:"bootstrapSpec"`
+		OCR2Spec      *jobSpecPresenter `json:"offChainReporting2Spec"`
+	} `json:"attributes"`
+}
+
+// contractID returns the contract the job is configured for or an empty string for other job types.
+func (p JobPresenter) contractID() string {
+	switch {
+	case p.Attributes.Type == jobTypeBootstrap && p.Attributes.BootstrapSpec != nil:
+		return p.Attributes.BootstrapSpec.ContractID
+	case p.Attributes.Type == jobTypeOCR2 && p.Attributes.OCR2Spec != nil:
+		return p.Attributes.OCR2Spec.ContractID
+	default:
+		return ""
+	}
+}
+
+// RepointRegistryJobs replaces the bootstrap job and the automation jobs that point at the previous registry address
+// with jobs for the provided registry. Jobs for other contracts are left unchanged.
+func RepointRegistryJobs(
+	ctx context.Context,
+	previous string,
+	registry config.AutomationRegistryV21Contract,
+	bootstrap *config.NodeConfig,
+	participants []config.NodeConfig,
+) error {
+	if dryRun(ctx, "replace jobs for registry %s with jobs for %s", previous, registry.Address) {
+		return nil
+	}
+
+	if bootstrap != nil {
+		client, err := authenticate(ctx, bootstrap.ManagementURL, bootstrap.LoginName, bootstrap.LoginPassword)
+		if err != nil {
+			return err
+		}
+
+		if err := deleteJobsFor(client, jobTypeBootstrap, previous); err != nil {
+			return err
+		}
+
+		if err := createBootstrapJob(client, registry.Address, bootstrap.ChainID); err != nil {
+			return err
+		}
+	}
+
+	for _, participant := range participants {
+		client, err := authenticate(ctx, participant.ManagementURL, participant.LoginName, participant.LoginPassword)
+		if err != nil {
+			return err
+		}
+
+		if err := deleteJobsFor(client, jobTypeOCR2, previous); err != nil {
+			return err
+		}
+
+		var bootstrapAddr string
+		if bootstrap != nil {
+			bootstrapAddr = bootstrap.BootstrapAddress
+		}
+
+		if err := createOCR2AutomationJob(client, AutomationJobConfig{
+			Version:           registry.ContractVersion(),
+			ContractAddr:      registry.Address,
+			NodeAddr:          participant.Address,
+			BootstrapNodeAddr: bootstrapAddr,
+			ChainID:           participant.ChainID,
+			MercuryCredName:   "cred1",
+		}); err != nil {
+			return fmt.Errorf("participant %s: %w", participant.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// getJobs returns all jobs on the chainlink node.
+func getJobs(client HTTPClient) ([]JobPresenter, error) {
+	rawResponse, err := nodeRequest(client, jobsEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+
+	var response dataResponse
+	if err := json.Unmarshal(rawResponse, &response); err != nil {
+		return nil, fmt.Errorf("not a data response: %w", err)
+	}
+
+	var jobs []JobPresenter
+	if err := json.Unmarshal(response.Data, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// deleteJobsFor deletes all jobs of the job type that are configured for the contract address.
+func deleteJobsFor(client HTTPClient, jobType, contractAddr string) error {
+	jobs, err := getJobs(client)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if job.Attributes.Type != jobType || !strings.EqualFold(job.contractID(), contractAddr) {
+			continue
+		}
+
+		if err := deleteJob(client, job.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deleteJob(client HTTPClient, jobID string) error {
+	resp, err := client.Delete(fmt.Sprintf("%s/%s", jobsEndpoint, jobID))
+	if err != nil {
+		return fmt.Errorf("failed to delete job %s: %s", jobID, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read error response body: %s", err)
+		}
+
+		return fmt.Errorf("unable to delete job %s: '%s' [%d]", jobID, string(body), resp.StatusCode)
+	}
+
+	return nil
+}
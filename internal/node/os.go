@@ -54,3 +54,25 @@ func writeFile(path, data string) error {
 
 	return nil
 }
+
+// removeSecrets deletes the secrets directory under the base path and removes the base path if nothing else remains.
+func removeSecrets(basePath string) error {
+	if err := os.RemoveAll(fmt.Sprintf("%s/secrets", basePath)); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(basePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if len(entries) == 0 {
+		return os.Remove(basePath)
+	}
+
+	return nil
+}
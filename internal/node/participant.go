@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/easterthebunny/automation-cli/internal/config"
+	cliio "github.com/easterthebunny/automation-cli/internal/io"
 	"github.com/easterthebunny/automation-cli/internal/restclient"
 )
 
@@ -16,15 +17,46 @@ type OCR2NodeConfig struct {
 	P2PKeyID          string
 }
 
+// NewParticipantConfig returns a docker hosted participant node configuration for the provided environment. The node
+// id determines the node name and management port.
+func NewParticipantConfig(env config.Environment, nodeID int, image, logLevel, keyAlias string) config.NodeConfig {
+	return config.NodeConfig{
+		HostType:      config.Docker,
+		Name:          fmt.Sprintf("participant-%d", nodeID),
+		Image:         image,
+		LogLevel:      logLevel,
+		ListenPort:    uint16(6688 + nodeID),
+		LoginName:     config.DefaultChainlinkNodeLogin,
+		LoginPassword: config.DefaultChainlinkNodePassword,
+
+		PrivateKeyAlias: keyAlias,
+		ChainID:         env.ChainID,
+		WSURL:           env.WSURL,
+		HTTPURL:         env.HTTPURL,
+		WSURLs:          env.WSURLs,
+		HTTPURLs:        env.HTTPURLs,
+
+		MercuryLegacyURL: config.DefaultMercuryLegacyURL,
+		MercuryURL:       config.DefaultMercuryURL,
+		MercuryID:        config.DefaultMercuryID,
+		MercuryKey:       config.DefaultMercuryKey,
+	}
+}
+
 func CreateParticipantNode(
 	ctx context.Context,
-	groupname, registryAddr string,
+	groupname string,
+	registry config.AutomationRegistryV21Contract,
 	bootstrap config.NodeConfig,
 	conf *config.NodeConfig,
 	basePath string,
 	privateKey *string,
 	reset bool,
 ) error {
+	if dryRun(ctx, "create participant node %s-%s", groupname, conf.Name) {
+		return nil
+	}
+
 	extraTOML := fmt.Sprintf("[P2P]\n[P2P.V2]\nListenAddresses = [\"0.0.0.0:%d\"]", bootstrap.BootstrapListenPort)
 
 	var err error
@@ -73,8 +105,8 @@ func CreateParticipantNode(
 
 	// create automation job
 	if err := createOCR2AutomationJob(client, AutomationJobConfig{
-		Version:           "v2.1",
-		ContractAddr:      registryAddr,
+		Version:           registry.ContractVersion(),
+		ContractAddr:      registry.Address,
 		NodeAddr:          clNode.Address,
 		BootstrapNodeAddr: bootstrap.BootstrapAddress,
 		ChainID:           conf.ChainID,
@@ -113,6 +145,10 @@ func RemoveParticipantNode(
 	groupname string,
 	conf config.NodeConfig,
 ) error {
+	if dryRun(ctx, "remove node %s-%s with postgres", groupname, conf.Name) {
+		return nil
+	}
+
 	return removeChainlinkNode(
 		ctx,
 		dockerNodeConfig{
@@ -123,3 +159,28 @@ func RemoveParticipantNode(
 		},
 	)
 }
+
+// RemoveNetwork deletes the docker network shared by all nodes in the group. A missing network is not an error.
+func RemoveNetwork(ctx context.Context, groupname string) error {
+	if dryRun(ctx, "remove docker network %s-local", groupname) {
+		return nil
+	}
+
+	return removeNetwork(ctx, groupname)
+}
+
+// RemoveNodeSecrets deletes the secrets written for a node at the provided node path.
+func RemoveNodeSecrets(basePath string) error {
+	return removeSecrets(basePath)
+}
+
+// dryRun prints the described action and reports true when the context is in dry run mode.
+func dryRun(ctx context.Context, format string, args ...any) bool {
+	if !cliio.DryRunFromContext(ctx) {
+		return false
+	}
+
+	fmt.Printf("dry run: would %s\n", fmt.Sprintf(format, args...))
+
+	return true
+}
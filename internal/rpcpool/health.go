@@ -0,0 +1,145 @@
+package rpcpool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// healthCheckTimeout is the longest a single endpoint health check may take
+	healthCheckTimeout = 5 * time.Second
+	// defaultMaxLag is the number of blocks an endpoint may trail the highest known block and still be preferred
+	defaultMaxLag uint64 = 3
+)
+
+// Health is the result of checking a single endpoint.
+type Health struct {
+	URL     string
+	Block   uint64
+	Lag     uint64
+	Latency time.Duration
+	Err     error
+}
+
+// Healthy reports whether the endpoint responded and is within the allowed block lag.
+func (h Health) Healthy() bool {
+	return h.Err == nil && h.Lag <= defaultMaxLag
+}
+
+// Status is a short description of the endpoint health.
+func (h Health) Status() string {
+	switch {
+	case h.Err != nil:
+		return fmt.Sprintf("error: %s", h.Err)
+	case h.Lag > defaultMaxLag:
+		return "lagging"
+	default:
+		return "healthy"
+	}
+}
+
+// Transport returns the transport type of the endpoint url.
+func (h Health) Transport() string {
+	scheme, _, _ := strings.Cut(h.URL, "://")
+
+	return strings.ToLower(scheme)
+}
+
+// CheckEndpoints reads the latest block number from each endpoint concurrently and measures the response time. Lag is
+// calculated against the highest block reported by any endpoint. Both HTTP and WS endpoints are supported.
+func CheckEndpoints(ctx context.Context, urls []string) []Health {
+	results := make([]Health, len(urls))
+
+	var wg sync.WaitGroup
+
+	for idx, url := range urls {
+		wg.Add(1)
+
+		go func(idx int, url string) {
+			defer wg.Done()
+
+			results[idx] = checkEndpoint(ctx, url)
+		}(idx, url)
+	}
+
+	wg.Wait()
+
+	var highest uint64
+
+	for _, result := range results {
+		if result.Err == nil && result.Block > highest {
+			highest = result.Block
+		}
+	}
+
+	for idx := range results {
+		if results[idx].Err == nil {
+			results[idx].Lag = highest - results[idx].Block
+		}
+	}
+
+	return results
+}
+
+func checkEndpoint(ctx context.Context, url string) Health {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	health := Health{URL: url}
+	start := time.Now()
+
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		health.Err = err
+
+		return health
+	}
+
+	defer client.Close()
+
+	var block hexutil.Uint64
+
+	if err := client.CallContext(ctx, &block, "eth_blockNumber"); err != nil {
+		health.Err = err
+
+		return health
+	}
+
+	health.Block = uint64(block)
+	health.Latency = time.Since(start)
+
+	return health
+}
+
+// rank orders health results from most to least preferred. Healthy endpoints come first ordered by lag and then
+// latency, followed by lagging endpoints and endpoints that returned errors.
+func rank(results []Health) []Health {
+	ranked := append([]Health(nil), results...)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		left, right := ranked[i], ranked[j]
+
+		if (left.Err == nil) != (right.Err == nil) {
+			return left.Err == nil
+		}
+
+		if left.Healthy() != right.Healthy() {
+			return left.Healthy()
+		}
+
+		if left.Lag != right.Lag {
+			return left.Lag < right.Lag
+		}
+
+		return left.Latency < right.Latency
+	})
+
+	return ranked
+}
@@ -0,0 +1,250 @@
+package rpcpool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	ErrNoEndpoints = fmt.Errorf("no rpc endpoints configured")
+	ErrAllFailed   = fmt.Errorf("all rpc endpoints failed")
+)
+
+const (
+	// poolURL is the placeholder url given to the rpc client; each request is routed to a pool endpoint
+	poolURL = "http://rpc-pool"
+	// checkInterval is how long endpoint health is trusted before it is checked again
+	checkInterval = 30 * time.Second
+	// failureCooldown is how long an endpoint that returned an error is moved to the end of the list
+	failureCooldown = time.Minute
+	// readRetries is the number of passes over all endpoints for requests that are safe to repeat
+	readRetries = 3
+	// retryBackoff is the wait between passes and is multiplied by the pass number
+	retryBackoff = time.Second
+)
+
+// writeMethods are the rpc methods that change chain state. Requests containing any of them are sent to a single
+// endpoint exactly once.
+//
+//nolint:gochecknoglobals
+var writeMethods = map[string]bool{
+	"eth_sendRawTransaction":   true,
+	"eth_sendTransaction":      true,
+	"personal_sendTransaction": true,
+}
+
+// Pool routes JSON-RPC requests over HTTP to the healthiest of several endpoints. Endpoints are ranked by block lag
+// and latency, and an endpoint that fails is skipped until its cooldown passes. Reads are retried on other endpoints
+// while writes are never sent more than once.
+type Pool struct {
+	mu        sync.Mutex
+	urls      []string
+	ranked    []Health
+	failed    map[string]time.Time
+	checkedAt time.Time
+	checking  bool
+	transport http.RoundTripper
+}
+
+// New creates a pool for the provided HTTP endpoint urls where the first url is preferred until health is checked.
+func New(urls []string) (*Pool, error) {
+	if len(urls) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	ranked := make([]Health, len(urls))
+	for idx, endpoint := range urls {
+		ranked[idx] = Health{URL: endpoint}
+	}
+
+	return &Pool{
+		urls:      urls,
+		ranked:    ranked,
+		failed:    make(map[string]time.Time),
+		transport: http.DefaultTransport,
+	}, nil
+}
+
+// Dial creates an rpc client that sends all requests through the pool.
+func (p *Pool) Dial(ctx context.Context) (*rpc.Client, error) {
+	return rpc.DialOptions(ctx, poolURL, rpc.WithHTTPClient(&http.Client{Transport: p}))
+}
+
+// Dial creates an rpc client that sends requests through a pool of the provided HTTP endpoints.
+func Dial(ctx context.Context, urls []string) (*rpc.Client, error) {
+	pool, err := New(urls)
+	if err != nil {
+		return nil, err
+	}
+
+	return pool.Dial(ctx)
+}
+
+// RoundTrip implements http.RoundTripper by sending the request to pool endpoints in order of preference.
+func (p *Pool) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = req.Body.Close()
+
+	candidates := p.candidates(req.Context())
+	write := isWrite(body)
+
+	var lastErr error
+
+	for pass := 0; pass < readRetries; pass++ {
+		if pass > 0 {
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(time.Duration(pass) * retryBackoff):
+			}
+		}
+
+		for _, endpoint := range candidates {
+			resp, err := p.send(req, endpoint, body)
+			if err == nil {
+				return resp, nil
+			}
+
+			lastErr = err
+
+			p.markFailed(endpoint)
+
+			if write {
+				// the endpoint may have received the transaction before failing
+				return nil, err
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrAllFailed, lastErr)
+}
+
+func (p *Pool) send(req *http.Request, endpoint string, body []byte) (*http.Response, error) {
+	target, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt := req.Clone(req.Context())
+	attempt.URL = target
+	attempt.Host = target.Host
+	attempt.Body = io.NopCloser(bytes.NewReader(body))
+	attempt.ContentLength = int64(len(body))
+
+	resp, err := p.transport.RoundTrip(attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	// rate limits and server errors are failures of the endpoint rather than of the request
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		_ = resp.Body.Close()
+
+		return nil, fmt.Errorf("%s: %s", endpoint, resp.Status)
+	}
+
+	return resp, nil
+}
+
+// candidates returns the endpoints in order of preference and refreshes health when it is out of date. Endpoints
+// that failed recently are moved to the end.
+func (p *Pool) candidates(ctx context.Context) []string {
+	p.refresh(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var preferred, cooling []string
+
+	for _, health := range p.ranked {
+		if failedAt, ok := p.failed[health.URL]; ok && time.Since(failedAt) < failureCooldown {
+			cooling = append(cooling, health.URL)
+
+			continue
+		}
+
+		preferred = append(preferred, health.URL)
+	}
+
+	return append(preferred, cooling...)
+}
+
+// refresh checks endpoint health when it is out of date. The check runs without holding the lock such that slow
+// endpoints do not block concurrent requests, which use the previous ranking until the check completes.
+func (p *Pool) refresh(ctx context.Context) {
+	p.mu.Lock()
+
+	stale := len(p.urls) > 1 && !p.checking && time.Since(p.checkedAt) > checkInterval
+	if stale {
+		p.checking = true
+	}
+
+	p.mu.Unlock()
+
+	if !stale {
+		return
+	}
+
+	ranked := rank(CheckEndpoints(ctx, p.urls))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.ranked = ranked
+	p.checkedAt = time.Now()
+	p.checking = false
+}
+
+func (p *Pool) markFailed(endpoint string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.failed[endpoint] = time.Now()
+}
+
+// isWrite reports whether the single or batch JSON-RPC request contains a state changing method. Requests that cannot
+// be read are treated as writes.
+func isWrite(body []byte) bool {
+	type message struct {
+		Method string `json:"method"`
+	}
+
+	var messages []message
+
+	trimmed := bytes.TrimSpace(body)
+
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &messages); err != nil {
+			return true
+		}
+	} else {
+		var single message
+
+		if err := json.Unmarshal(trimmed, &single); err != nil {
+			return true
+		}
+
+		messages = append(messages, single)
+	}
+
+	for _, msg := range messages {
+		if writeMethods[msg.Method] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,132 @@
+package rpcpool_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/easterthebunny/automation-cli/internal/rpcpool"
+)
+
+func TestPool_FailoverReads(t *testing.T) {
+	t.Parallel()
+
+	var failedCalls, healthyCalls atomic.Int64
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		failedCalls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer failing.Close()
+
+	healthy := newServer(t, &healthyCalls, "0x10", false)
+	defer healthy.Close()
+
+	client, err := rpcpool.Dial(context.Background(), []string{failing.URL, healthy.URL})
+	require.NoError(t, err)
+
+	var block hexutil.Uint64
+
+	require.NoError(t, client.CallContext(context.Background(), &block, "eth_blockNumber"))
+	assert.Equal(t, uint64(16), uint64(block))
+	assert.Positive(t, healthyCalls.Load())
+}
+
+func TestPool_WritesAreNotRetried(t *testing.T) {
+	t.Parallel()
+
+	var firstCalls, secondCalls atomic.Int64
+
+	// the first endpoint is ahead and preferred but fails on sending transactions
+	first := newServer(t, &firstCalls, "0x20", true)
+	defer first.Close()
+
+	second := newServer(t, &secondCalls, "0x10", false)
+	defer second.Close()
+
+	client, err := rpcpool.Dial(context.Background(), []string{first.URL, second.URL})
+	require.NoError(t, err)
+
+	err = client.CallContext(context.Background(), nil, "eth_sendRawTransaction", "0x00")
+
+	require.Error(t, err)
+	assert.Equal(t, int64(2), firstCalls.Load(), "health check plus one send")
+	assert.Equal(t, int64(1), secondCalls.Load(), "health check only")
+}
+
+func TestPool_HealthCheckDoesNotBlockRequests(t *testing.T) {
+	t.Parallel()
+
+	var fastCalls atomic.Int64
+
+	fast := newServer(t, &fastCalls, "0x10", false)
+	defer fast.Close()
+
+	started, release := make(chan struct{}), make(chan struct{})
+
+	var once sync.Once
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		once.Do(func() { close(started) })
+		<-release
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	client, err := rpcpool.Dial(context.Background(), []string{fast.URL, slow.URL})
+	require.NoError(t, err)
+
+	// the first request checks health and waits on the slow endpoint
+	go func() {
+		_ = client.CallContext(context.Background(), nil, "eth_blockNumber")
+	}()
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var block hexutil.Uint64
+
+	require.NoError(t, client.CallContext(ctx, &block, "eth_blockNumber"))
+	assert.Equal(t, uint64(16), uint64(block))
+}
+
+func newServer(t *testing.T, calls *atomic.Int64, block string, failWrites bool) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		if req.Method == "eth_sendRawTransaction" && failWrites {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": block})
+	}))
+}
@@ -141,6 +141,10 @@ type (
 // Avoid using this in tests.
 var DefaultScryptParams = ScryptParams{N: keystore.StandardScryptN, P: keystore.StandardScryptP}
 
+// LightScryptParams uses geth's light level of encryption. It is intended for keys that are decrypted on every command
+// where the standard level would add seconds of delay per key.
+var LightScryptParams = ScryptParams{N: keystore.LightScryptN, P: keystore.LightScryptP}
+
 // FastScryptParams is for use in tests, where you don't want to wear out your
 // CPU with expensive key derivations, do not use it in production, or your
 // encrypted keys will be easy to brute-force!